@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"git-report-generator/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// auditCmd checks the matched commits against the policies of the audit
+// configuration and reports violations without generating a report
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List matched commits violating the configured commit policies",
+	Long: `Check every matched commit against the policies in the "audit" section of
+the configuration (signed, Conventional Commits subject, subject length, ticket
+reference) and list the violations. Exits with a non-zero status if any commit
+fails a policy.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runAudit,
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	reportData, err := loadReportData()
+	if err != nil {
+		return err
+	}
+
+	violations, err := generator.Audit(reportData.Commits, reportData.Config.Audit)
+	if err != nil {
+		return err
+	}
+
+	for _, violation := range violations {
+		commit := violation.Commit
+		fmt.Printf("%s  %s  %s\n", commit.Date.Format("2006-01-02"), commit.SHA, commit.Message)
+		for _, problem := range violation.Problems {
+			fmt.Printf("    ✗ %s\n", problem)
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%d of %d commits violate the audit policies", len(violations), len(reportData.Commits))
+	}
+	fmt.Printf("✅ All %d commits satisfy the audit policies\n", len(reportData.Commits))
+	return nil
+}
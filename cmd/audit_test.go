@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditRejectsUnsignedCommits(t *testing.T) {
+	repo := newTestRepo(t, "feat: add login")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("audit:\n  require_signed: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	args := []string{"audit", "--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31", "--author", testAuthor.Email}
+	if err := execute(t, args...); err != nil {
+		t.Errorf("expected the default policies to pass, got %v", err)
+	}
+	err := execute(t, append(args, "--config", configPath)...)
+	if err == nil || !strings.Contains(err.Error(), "1 of 1 commits violate") {
+		t.Errorf("expected the unsigned commit to fail the audit, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestRepeatedAuthorFlag(t *testing.T) {
+	dir := newTestRepo(t, "Jan change")
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	for _, author := range []object.Signature{
+		{Name: "Anna Nowak", Email: "anna@example.com"},
+		{Name: "Ewa Zielińska", Email: "ewa@example.com"},
+	} {
+		author.When = time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)
+		if _, err := worktree.Commit(author.Name+" change", &git.CommitOptions{Author: &author, AllowEmptyCommits: true}); err != nil {
+			t.Fatalf("failed to commit: %v", err)
+		}
+	}
+
+	output := filepath.Join(t.TempDir(), "report.md")
+	if err := execute(t, "--repo", dir, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--author", "anna@example.com", "--output", output); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	report := string(content)
+	for _, want := range []string{
+		"**Jan change**",
+		"**Anna Nowak change**",
+		"- Autorzy:\n  - Jan Kowalski <jan@example.com>: 1\n  - Anna Nowak <anna@example.com>: 1\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Ewa") {
+		t.Errorf("unexpected commit of an author not given:\n%s", report)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"git-report-generator/internal/git"
+)
+
+// mergeBranchCommits adds the commits of the extra branches matching the
+// query to commits, skipping those already included through an earlier
+// branch. It returns the merged commits in report order and the tip hashes
+// of the extra branches.
+func mergeBranchCommits(gitService *git.Service, query git.CommitQuery, commits []*git.Commit, extra []string) ([]*git.Commit, []string, error) {
+	seen := make(map[string]bool, len(commits))
+	for _, commit := range commits {
+		seen[commit.Hash] = true
+	}
+
+	var tips []string
+	for _, name := range extra {
+		tip, err := gitService.GetBranchHash(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get branch tip hash of %s: %w", name, err)
+		}
+		tips = append(tips, tip)
+
+		query.Branch = name
+		branchCommits, err := gitService.GetCommits(query)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get commits of branch %s: %w", name, err)
+		}
+		for _, commit := range branchCommits {
+			if !seen[commit.Hash] {
+				seen[commit.Hash] = true
+				commits = append(commits, commit)
+			}
+		}
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		if query.Oldest {
+			return commits[i].Date.Before(commits[j].Date)
+		}
+		return commits[j].Date.Before(commits[i].Date)
+	})
+	if query.Limit > 0 && len(commits) > query.Limit {
+		commits = commits[:query.Limit]
+	}
+
+	return commits, tips, nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// newTwoBranchRepo returns a repository where master and release/1.0 share
+// the first commit and each add one of their own
+func newTwoBranchRepo(t *testing.T) string {
+	t.Helper()
+	dir := newTestRepo(t, "Shared change")
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	checkout := func(branch string, create bool) {
+		t.Helper()
+		if err := worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch), Create: create}); err != nil {
+			t.Fatalf("failed to check out %s: %v", branch, err)
+		}
+	}
+	checkout("release/1.0", true)
+	commitFile(t, repo, dir, "Release fix", time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC))
+	checkout("master", false)
+	commitFile(t, repo, dir, "Master feature", time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC))
+	return dir
+}
+
+func TestBranchListMergesAndDedupes(t *testing.T) {
+	dir := newTwoBranchRepo(t)
+	output := filepath.Join(t.TempDir(), "report.json")
+	if err := execute(t, "--repo", dir, "--branch", "master", "--branch", "release/1.0", "--from", "2024-01-01",
+		"--to", "2024-01-31", "--author", testAuthor.Email, "--format", "json", "--output", output); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var commits []struct {
+		Subject string `json:"subject"`
+		Branch  string `json:"branch"`
+	}
+	if err := json.Unmarshal(content, &commits); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	var subjects []string
+	branches := map[string]string{}
+	for _, commit := range commits {
+		subjects = append(subjects, commit.Subject)
+		branches[commit.Subject] = commit.Branch
+	}
+	// The shared commit is listed once, newest first
+	if want := []string{"Release fix", "Master feature", "Shared change"}; !reflect.DeepEqual(subjects, want) {
+		t.Errorf("subjects = %q, want %q", subjects, want)
+	}
+	if branches["Release fix"] != "release/1.0" || branches["Shared change"] != "master" {
+		t.Errorf("branches = %v, want shared commits credited to the first branch", branches)
+	}
+}
+
+func TestScopeBranchAndRepo(t *testing.T) {
+	dir := newTwoBranchRepo(t)
+
+	subjects := func(args ...string) []string {
+		t.Helper()
+		output := filepath.Join(t.TempDir(), "report.json")
+		args = append([]string{"--repo", dir, "--branch", "master", "--from", "2024-01-01", "--to", "2024-01-31",
+			"--author", testAuthor.Email, "--format", "json", "--output", output}, args...)
+		if err := execute(t, args...); err != nil {
+			t.Fatalf("failed to generate report with %q: %v", args, err)
+		}
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		var commits []struct {
+			Subject string `json:"subject"`
+		}
+		if err := json.Unmarshal(content, &commits); err != nil {
+			t.Fatalf("failed to parse report: %v", err)
+		}
+		var got []string
+		for _, commit := range commits {
+			got = append(got, commit.Subject)
+		}
+		return got
+	}
+
+	if got, want := subjects(), []string{"Master feature", "Shared change"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("default branch scope = %q, want %q", got, want)
+	}
+	if got, want := subjects("--scope", "branch"), []string{"Master feature", "Shared change"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("branch scope = %q, want %q", got, want)
+	}
+	if got, want := subjects("--scope", "repo"), []string{"Release fix", "Master feature", "Shared change"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("repo scope = %q, want the unmerged release commit too: %q", got, want)
+	}
+	if err := execute(t, "--repo", dir, "--scope", "everything", "--author", testAuthor.Email); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+// cloneBackoff is the delay before the first retry of a failed clone
+const cloneBackoff = time.Second
+
+// openRepository opens the repository given with --repo. A remote URL is
+// cloned into a temporary directory first, which the returned cleanup
+// function removes again, or with --keep-clone into a kept directory that
+// later runs only fetch into.
+func openRepository() (*git.Service, func(), error) {
+	if !git.IsRemoteURL(repoPath) {
+		absRepoPath, err := filepath.Abs(repoPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get absolute path for repository: %w", err)
+		}
+
+		gitService, err := git.NewService(absRepoPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize Git service: %w", err)
+		}
+		return gitService, func() {}, nil
+	}
+
+	if retryClone < 0 {
+		return nil, nil, fmt.Errorf("retry clone count cannot be negative")
+	}
+
+	opts := git.CloneOptions{Retries: retryClone, Backoff: cloneBackoff}
+	if verbose {
+		opts.Logf = func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}
+	}
+
+	if keepClone != "" {
+		return openKeptClone(keepClone, opts)
+	}
+
+	tempDir, err := os.MkdirTemp("", "git-report-clone-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create clone directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tempDir) }
+
+	// Clone into a directory named like the remote so it names the report
+	cloneDir := filepath.Join(tempDir, git.RemoteName(repoPath))
+	if err := git.Clone(repoPath, cloneDir, opts); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	gitService, err := git.NewService(cloneDir)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to initialize Git service: %w", err)
+	}
+	return gitService, cleanup, nil
+}
+
+// openKeptClone clones the remote into dir on the first run and fetches into
+// the existing clone on later ones. The clone is never removed.
+func openKeptClone(dir string, opts git.CloneOptions) (*git.Service, func(), error) {
+	if _, err := os.Stat(dir); err == nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Fetching %s into existing clone %s\n", repoPath, dir)
+		}
+		if err := git.Fetch(repoPath, dir, opts); err != nil {
+			return nil, nil, err
+		}
+	} else if os.IsNotExist(err) {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Cloning %s into %s\n", repoPath, dir)
+		}
+		if err := git.Clone(repoPath, dir, opts); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		return nil, nil, fmt.Errorf("failed to check clone directory: %w", err)
+	}
+
+	gitService, err := git.NewService(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize Git service: %w", err)
+	}
+	return gitService, func() {}, nil
+}
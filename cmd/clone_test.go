@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestKeepCloneFetchesOnSecondRun(t *testing.T) {
+	source := newTestRepo(t, "First change")
+	url := "file://" + source
+	kept := filepath.Join(t.TempDir(), "kept")
+
+	run := func() int {
+		t.Helper()
+		output := filepath.Join(t.TempDir(), "report.json")
+		if err := execute(t, "--repo", url, "--keep-clone", kept, "--from", "2024-01-01", "--to", "2024-01-31",
+			"--author", testAuthor.Email, "--format", "json", "--output", output); err != nil {
+			t.Fatalf("failed to generate report: %v", err)
+		}
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		var commits []map[string]interface{}
+		if err := json.Unmarshal(content, &commits); err != nil {
+			t.Fatalf("failed to parse report: %v", err)
+		}
+		return len(commits)
+	}
+
+	if got := run(); got != 1 {
+		t.Fatalf("first run reported %d commits, want 1", got)
+	}
+	// A fresh clone would wipe the directory, a fetch leaves the marker alone
+	marker := filepath.Join(kept, "marker")
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		t.Fatalf("failed to write marker: %v", err)
+	}
+
+	repo, err := git.PlainOpen(source)
+	if err != nil {
+		t.Fatalf("failed to open source repository: %v", err)
+	}
+	commitFile(t, repo, source, "Second change", time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC))
+
+	if got := run(); got != 2 {
+		t.Errorf("second run reported %d commits, want the fetched commit too", got)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("expected the kept clone to be fetched into rather than cloned again: %v", err)
+	}
+}
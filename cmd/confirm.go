@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// confirmLargeReport warns when a report has more commits than the threshold
+// and, when stdin is a terminal, asks whether to continue. --yes and runs
+// with piped input only get the warning.
+func confirmLargeReport(count, threshold int) error {
+	return confirmReport(os.Stdin, isTerminal(os.Stdin), count, threshold)
+}
+
+// confirmReport is confirmLargeReport reading the answer from in, which is
+// only prompted when interactive
+func confirmReport(in io.Reader, interactive bool, count, threshold int) error {
+	if threshold == 0 || count <= threshold {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  The report includes %d commits (more than %d), the output may be very large\n", count, threshold)
+	if assumeYes || !interactive {
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, "Continue? [y/N] ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("report not confirmed, rerun with --yes to skip the confirmation")
+}
+
+// isTerminal reports whether the file is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
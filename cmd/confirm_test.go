@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfirmReport(t *testing.T) {
+	t.Cleanup(func() { assumeYes = false })
+	for _, tc := range []struct {
+		answer      string
+		interactive bool
+		yes         bool
+		count       int
+		wantErr     bool
+	}{
+		{answer: "\n", interactive: true, count: 5, wantErr: true},
+		{answer: "n\n", interactive: true, count: 5, wantErr: true},
+		{answer: "", interactive: true, count: 5, wantErr: true},
+		{answer: "y\n", interactive: true, count: 5},
+		{answer: "YES\n", interactive: true, count: 5},
+		{answer: "", interactive: true, yes: true, count: 5},
+		// Scripts with piped input are only warned
+		{answer: "", interactive: false, count: 5},
+		{answer: "", interactive: true, count: 3},
+	} {
+		assumeYes = tc.yes
+		err := confirmReport(strings.NewReader(tc.answer), tc.interactive, tc.count, 3)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("confirmReport(%q, interactive %v, yes %v, %d) = %v, want error %v",
+				tc.answer, tc.interactive, tc.yes, tc.count, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMaxCommitsCap(t *testing.T) {
+	repo := newTestRepo(t, "First change", "Second change")
+	args := []string{"--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31", "--author", testAuthor.Email,
+		"--format", "json", "--output", filepath.Join(t.TempDir(), "report.json")}
+
+	err := execute(t, append(args, "--max-commits", "1", "--yes")...)
+	if err == nil || !strings.Contains(err.Error(), "more than --max-commits 1") {
+		t.Errorf("expected the cap to fail the run even with --yes, got %v", err)
+	}
+	if err := execute(t, append(args, "--max-commits", "2")...); err != nil {
+		t.Errorf("expected a report at the cap to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+// parseDate parses an absolute date (YYYY-MM-DD), "today", or a relative date
+// such as "7d", "2w", "1m" or "1y" meaning that long before today
+func parseDate(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if value == "today" {
+		return today, nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	if len(value) < 2 {
+		return time.Time{}, fmt.Errorf("invalid date %q. Use YYYY-MM-DD or a relative date like 7d, 2w, 1m, 1y", value)
+	}
+
+	amount, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || amount < 0 {
+		return time.Time{}, fmt.Errorf("invalid date %q. Use YYYY-MM-DD or a relative date like 7d, 2w, 1m, 1y", value)
+	}
+
+	switch value[len(value)-1] {
+	case 'd':
+		return today.AddDate(0, 0, -amount), nil
+	case 'w':
+		return today.AddDate(0, 0, -7*amount), nil
+	case 'm':
+		return today.AddDate(0, -amount, 0), nil
+	case 'y':
+		return today.AddDate(-amount, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid date %q. Use YYYY-MM-DD or a relative date like 7d, 2w, 1m, 1y", value)
+	}
+}
+
+// resolveTimezone returns the zone the date range is interpreted in. An
+// explicit --timezone wins. With --tz-from-git-config the gitreport.timezone
+// git config option is used next. Otherwise the range is interpreted in the
+// machine's local zone, honoring the TZ environment variable.
+func resolveTimezone(gitService *git.Service) (*time.Location, error) {
+	name := timezone
+	if name == "" && tzFromGit {
+		value, err := gitService.GetConfigValue("gitreport", "timezone")
+		if err != nil {
+			return nil, err
+		}
+		name = value
+	}
+	if name == "" {
+		return localZone(), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// localZone returns the machine's zone loaded by its IANA name, so reports
+// record a zone that verifies on machines in other zones. It prefers TZ, then
+// the /etc/localtime link, and falls back to time.Local when neither names a
+// zone.
+func localZone() *time.Location {
+	name, set := os.LookupEnv("TZ")
+	if set && name == "" {
+		// An empty TZ means UTC
+		return time.UTC
+	}
+	name = strings.TrimPrefix(name, ":")
+	if name == "" {
+		if target, err := os.Readlink("/etc/localtime"); err == nil {
+			if _, zone, found := strings.Cut(target, "zoneinfo/"); found {
+				name = zone
+			}
+		}
+	}
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// readDateFile returns the date written to the file, in any form parseDate
+// accepts, with surrounding whitespace removed
+func readDateFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read date file: %w", err)
+	}
+
+	value := strings.TrimSpace(string(content))
+	if value == "" {
+		return "", fmt.Errorf("date file %s is empty", path)
+	}
+	return value, nil
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestTimezoneFromGitConfigMovesBoundaryCommit(t *testing.T) {
+	dir := newTestRepo(t, "Early January")
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	// Still January 31st in UTC, already February 1st in Tokyo
+	commitFile(t, repo, dir, "Late evening", time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC))
+
+	gitConfig, err := os.OpenFile(filepath.Join(dir, ".git", "config"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open git config: %v", err)
+	}
+	if _, err := gitConfig.WriteString("[gitreport]\n\ttimezone = Asia/Tokyo\n"); err != nil {
+		t.Fatalf("failed to write git config: %v", err)
+	}
+	gitConfig.Close()
+	t.Setenv("TZ", "UTC")
+
+	for _, tc := range []struct {
+		fromGit bool
+		want    int
+	}{
+		{false, 2},
+		{true, 1},
+	} {
+		output := filepath.Join(t.TempDir(), "report.json")
+		args := []string{"--repo", dir, "--from", "2024-01-01", "--to", "2024-01-31",
+			"--author", testAuthor.Email, "--format", "json", "--output", output}
+		if tc.fromGit {
+			args = append(args, "--tz-from-git-config")
+		}
+		if err := execute(t, args...); err != nil {
+			t.Fatalf("tz from git config %v: failed to generate report: %v", tc.fromGit, err)
+		}
+
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		var commits []map[string]interface{}
+		if err := json.Unmarshal(content, &commits); err != nil {
+			t.Fatalf("failed to parse report: %v", err)
+		}
+		if len(commits) != tc.want {
+			t.Errorf("tz from git config %v: %d commits, want %d", tc.fromGit, len(commits), tc.want)
+		}
+	}
+}
+
+func TestReadDateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "from.txt")
+	if err := os.WriteFile(path, []byte("  2024-01-03\n"), 0644); err != nil {
+		t.Fatalf("failed to write date file: %v", err)
+	}
+	if value, err := readDateFile(path); err != nil || value != "2024-01-03" {
+		t.Errorf("readDateFile = %q, %v, want 2024-01-03", value, err)
+	}
+
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte(" \n"), 0644); err != nil {
+		t.Fatalf("failed to write date file: %v", err)
+	}
+	if _, err := readDateFile(empty); err == nil {
+		t.Error("expected an error for an empty date file")
+	}
+	if _, err := readDateFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("expected an error for a missing date file")
+	}
+}
+
+func TestDateFilesMatchFlags(t *testing.T) {
+	repo := newTestRepo(t, "First", "Second", "Third", "Fourth")
+	dir := t.TempDir()
+	fromFile, toFile := filepath.Join(dir, "from.txt"), filepath.Join(dir, "to.txt")
+	if err := os.WriteFile(fromFile, []byte("2024-01-03\n"), 0644); err != nil {
+		t.Fatalf("failed to write date file: %v", err)
+	}
+	if err := os.WriteFile(toFile, []byte("2024-01-04\n"), 0644); err != nil {
+		t.Fatalf("failed to write date file: %v", err)
+	}
+
+	count := func(args ...string) int {
+		t.Helper()
+		output := filepath.Join(t.TempDir(), "report.json")
+		args = append([]string{"--repo", repo, "--author", testAuthor.Email, "--format", "json", "--output", output}, args...)
+		if err := execute(t, args...); err != nil {
+			t.Fatalf("failed to generate report with %q: %v", args, err)
+		}
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		var commits []map[string]interface{}
+		if err := json.Unmarshal(content, &commits); err != nil {
+			t.Fatalf("failed to parse report: %v", err)
+		}
+		return len(commits)
+	}
+
+	fromFlags := count("--from", "2024-01-03", "--to", "2024-01-04")
+	if fromFlags != 2 {
+		t.Fatalf("expected 2 commits from the flags, got %d", fromFlags)
+	}
+	if got := count("--from-file", fromFile, "--to-file", toFile); got != fromFlags {
+		t.Errorf("date files matched %d commits, want %d as with the flags", got, fromFlags)
+	}
+	// Flags take precedence over the files
+	if got := count("--from", "2024-01-01", "--from-file", fromFile, "--to-file", toFile); got != 3 {
+		t.Errorf("expected --from to override --from-file, got %d commits", got)
+	}
+}
+
+func TestTimezoneDefaultsToLocalZone(t *testing.T) {
+	dir := newTestRepo(t, "Early January")
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	// Still January 31st in UTC, already February 1st in Tokyo
+	commitFile(t, repo, dir, "Late evening", time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC))
+	t.Setenv("TZ", "Asia/Tokyo")
+
+	for _, tc := range []struct {
+		args []string
+		want int
+	}{
+		{nil, 1},
+		{[]string{"--timezone", "UTC"}, 2},
+	} {
+		output := filepath.Join(t.TempDir(), "report.json")
+		args := append([]string{"--repo", dir, "--from", "2024-01-01", "--to", "2024-01-31",
+			"--author", testAuthor.Email, "--format", "json", "--output", output}, tc.args...)
+		if err := execute(t, args...); err != nil {
+			t.Fatalf("%v: failed to generate report: %v", tc.args, err)
+		}
+
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		var commits []map[string]interface{}
+		if err := json.Unmarshal(content, &commits); err != nil {
+			t.Fatalf("failed to parse report: %v", err)
+		}
+		if len(commits) != tc.want {
+			t.Errorf("%v: %d commits, want %d", tc.args, len(commits), tc.want)
+		}
+	}
+
+	err = execute(t, "--repo", dir, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--timezone", "Mars/Olympus")
+	if err == nil || !strings.Contains(err.Error(), "invalid timezone") {
+		t.Errorf("expected an invalid timezone error, got %v", err)
+	}
+}
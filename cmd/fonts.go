@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"git-report-generator/fonts"
+	"git-report-generator/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// fontsCmd groups the font management subcommands
+var fontsCmd = &cobra.Command{
+	Use:   "fonts",
+	Short: "Manage the fonts used for PDF reports",
+}
+
+// fontsInstallCmd writes the embedded fonts to disk, by default into the
+// directory PDF reports load them from
+var fontsInstallCmd = &cobra.Command{
+	Use:   "install [dir]",
+	Short: "Write the embedded DejaVu fonts to a directory (default: fonts next to the executable)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runFontsInstall,
+}
+
+func init() {
+	fontsCmd.AddCommand(fontsInstallCmd)
+}
+
+func runFontsInstall(cmd *cobra.Command, args []string) error {
+	dir := ""
+	if len(args) == 1 {
+		dir = args[0]
+	} else {
+		defaultDir, err := generator.DefaultFontDir()
+		if err != nil {
+			return err
+		}
+		dir = defaultDir
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create font directory: %w", err)
+	}
+
+	for _, name := range fonts.Files {
+		content, err := fonts.FS.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded font %s: %w", name, err)
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write font %s: %w", path, err)
+		}
+		fmt.Printf("🔤 %s\n", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-report-generator/fonts"
+)
+
+func TestFontsInstallWritesFonts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "fonts")
+	if err := execute(t, "fonts", "install", dir); err != nil {
+		t.Fatalf("fonts install failed: %v", err)
+	}
+
+	for _, name := range []string{"DejaVuSans.ttf", "DejaVuSans-Bold.ttf", "DejaVuSans-Oblique.ttf"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("expected %s to be installed: %v", name, err)
+			continue
+		}
+		embedded, err := fonts.FS.ReadFile(name)
+		if err != nil {
+			t.Fatalf("failed to read embedded %s: %v", name, err)
+		}
+		if !bytes.Equal(content, embedded) {
+			t.Errorf("installed %s differs from the embedded font", name)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// testAuthor is the author of test commits
+var testAuthor = object.Signature{Name: "Jan Kowalski", Email: "jan@example.com"}
+
+// newTestRepo initializes a repository in a temporary directory with a commit
+// per subject, made on consecutive days of January 2024 starting on the 2nd
+func newTestRepo(t *testing.T, subjects ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+	for i, subject := range subjects {
+		commitFile(t, repo, dir, subject, time.Date(2024, 1, i+2, 10, 0, 0, 0, time.UTC))
+	}
+	return dir
+}
+
+// commitFile changes a file of the repository and commits it as testAuthor
+func commitFile(t *testing.T, repo *git.Repository, dir, subject string, when time.Time) {
+	t.Helper()
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(subject), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := worktree.Add("file.txt"); err != nil {
+		t.Fatalf("failed to stage file: %v", err)
+	}
+	author := testAuthor
+	author.When = when
+	if _, err := worktree.Commit(subject, &git.CommitOptions{Author: &author}); err != nil {
+		t.Fatalf("failed to commit %q: %v", subject, err)
+	}
+}
+
+// execute runs the command line with every flag and derived filter reset to
+// its default first, so earlier runs in the same process do not leak into it.
+// The user configuration is isolated in a temporary directory.
+func execute(t *testing.T, args ...string) error {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	for _, command := range append([]*cobra.Command{rootCmd}, rootCmd.Commands()...) {
+		command.Flags().VisitAll(reset)
+	}
+	authorEmail, branch, verifiedSHAs = "", "", nil
+
+	rootCmd.SetArgs(args)
+	return rootCmd.Execute()
+}
+
+// captureOutput runs fn with stdout and stderr redirected and returns what
+// was written to each
+func captureOutput(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+	capture := func(target **os.File) (restore func() string) {
+		read, write, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("failed to create pipe: %v", err)
+		}
+		original := *target
+		*target = write
+		done := make(chan string)
+		go func() {
+			var buf bytes.Buffer
+			buf.ReadFrom(read)
+			done <- buf.String()
+		}()
+		return func() string {
+			*target = original
+			write.Close()
+			return <-done
+		}
+	}
+
+	restoreStdout := capture(&os.Stdout)
+	restoreStderr := capture(&os.Stderr)
+	defer func() {
+		stdout, stderr = restoreStdout(), restoreStderr()
+	}()
+	fn()
+	return
+}
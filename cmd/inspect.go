@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"git-report-generator/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+// inspectCmd prints what the tool resolves about the repository, which helps
+// finding out why filters match no commits
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Print the resolved repository metadata",
+	Args:  cobra.NoArgs,
+	RunE:  runInspect,
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for repository: %w", err)
+	}
+
+	gitService, err := git.NewService(absRepoPath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Git service: %w", err)
+	}
+
+	// Missing values are reported inline so the remaining ones still print
+	orNote := func(value string, err error) string {
+		switch {
+		case err != nil:
+			return fmt.Sprintf("(%v)", err)
+		case value == "":
+			return "(not set)"
+		default:
+			return value
+		}
+	}
+
+	branchName, branchErr := gitService.GetCurrentBranch()
+	headHash, headErr := gitService.GetHeadHash()
+	userEmail, emailErr := gitService.GetUserEmail()
+	remoteURL, remoteErr := gitService.GetRemoteURL()
+
+	commitCount := ""
+	count, countErr := gitService.CountCommits()
+	if countErr == nil {
+		commitCount = fmt.Sprintf("%d", count)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Repository path:\t%s\n", gitService.GetRepositoryPath())
+	fmt.Fprintf(w, "Repository name:\t%s\n", gitService.GetRepositoryName())
+	fmt.Fprintf(w, "Current branch:\t%s\n", orNote(branchName, branchErr))
+	fmt.Fprintf(w, "HEAD:\t%s\n", orNote(headHash, headErr))
+	fmt.Fprintf(w, "User email:\t%s\n", orNote(userEmail, emailErr))
+	fmt.Fprintf(w, "Remote URL:\t%s\n", orNote(remoteURL, remoteErr))
+	fmt.Fprintf(w, "Commits on HEAD:\t%s\n", orNote(commitCount, countErr))
+
+	return w.Flush()
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInspectPrintsRepositoryMetadata(t *testing.T) {
+	dir := newTestRepo(t, "First change", "Second change")
+	gitConfig := "[user]\n\temail = jan@example.com\n[remote \"origin\"]\n\turl = https://github.com/acme/portal.git\n"
+	if err := os.WriteFile(filepath.Join(dir, ".git", "config"), []byte(gitConfig), 0644); err != nil {
+		t.Fatalf("failed to write git config: %v", err)
+	}
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	if err := execute(t, "inspect", "--repo", dir); err != nil {
+		t.Fatalf("inspect failed: %v", err)
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		key, value, _ := strings.Cut(line, ":")
+		values[key] = strings.TrimSpace(value)
+	}
+	for key, want := range map[string]string{
+		"Repository path": dir,
+		"Repository name": filepath.Base(dir),
+		"Current branch":  "master",
+		"User email":      "jan@example.com",
+		"Remote URL":      "https://github.com/acme/portal.git",
+		"Commits on HEAD": "2",
+	} {
+		if values[key] != want {
+			t.Errorf("%s = %q, want %q", key, values[key], want)
+		}
+	}
+	if head := values["HEAD"]; len(head) != 40 {
+		t.Errorf("HEAD = %q, want a full hash", head)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"git-report-generator/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+// listCmd prints the matched commits as a plain-text table without
+// generating a report, which is handy for checking filters
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print the matched commits as a plain-text table",
+	RunE:  runList,
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	reportData, err := loadReportData()
+	if err != nil {
+		return err
+	}
+
+	return writeCommitList(os.Stdout, reportData.Commits)
+}
+
+// writeCommitList writes the commits as a table with aligned columns
+func writeCommitList(out io.Writer, commits []*git.Commit) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tSHA\tSUBJECT")
+	for _, commit := range commits {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", commit.Date.Format("2006-01-02"), commit.SHA, commit.Message)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+func TestWriteCommitListAlignsColumns(t *testing.T) {
+	commits := []*git.Commit{
+		{SHA: "abc1234", Date: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC), Message: "Add login form"},
+		{SHA: "def56789ab", Date: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC), Message: "Initial commit"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCommitList(&buf, commits); err != nil {
+		t.Fatalf("writeCommitList failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want a header and a row per commit:\n%s", len(lines), buf.String())
+	}
+
+	for i, commit := range commits {
+		line := lines[i+1]
+		if !strings.HasPrefix(line, commit.Date.Format("2006-01-02")) || !strings.HasSuffix(line, commit.Message) {
+			t.Errorf("row %d = %q, want the date and subject of %s", i+1, line, commit.SHA)
+		}
+	}
+
+	// Every column starts at the same offset on every line
+	shaOffset, subjectOffset := strings.Index(lines[0], "SHA"), strings.Index(lines[0], "SUBJECT")
+	for i, commit := range commits {
+		line := lines[i+1]
+		if got := strings.Index(line, commit.SHA); got != shaOffset {
+			t.Errorf("SHA of row %d starts at %d, want %d", i+1, got, shaOffset)
+		}
+		if got := strings.Index(line, commit.Message); got != subjectOffset {
+			t.Errorf("subject of row %d starts at %d, want %d", i+1, got, subjectOffset)
+		}
+	}
+}
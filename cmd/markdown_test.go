@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownFormatFromExtension(t *testing.T) {
+	repo := newTestRepo(t, "First change")
+	report := filepath.Join(t.TempDir(), "report.md")
+
+	if err := execute(t, "--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--output", report); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+	content, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatalf("expected the Markdown report: %v", err)
+	}
+	if !strings.Contains(string(content), "**First change**") {
+		t.Errorf("expected a Markdown report, got:\n%s", content)
+	}
+}
+
+func TestMarkdownWrittenWithoutCommits(t *testing.T) {
+	repo := newTestRepo(t, "First change")
+	report := filepath.Join(t.TempDir(), "report.txt")
+
+	if err := execute(t, "--repo", repo, "--from", "2023-01-01", "--to", "2023-01-31", "--author", testAuthor.Email,
+		"--format", "md", "--output", report); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+	content, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatalf("expected a Markdown report without commits: %v", err)
+	}
+	if !strings.Contains(string(content), "Brak commitów") {
+		t.Errorf("expected the empty note, got:\n%s", content)
+	}
+}
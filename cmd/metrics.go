@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"git-report-generator/internal/generator"
+)
+
+// Metrics receives the metrics of every generate run when set, either by a
+// program embedding the command or through --metrics-push. Nil disables them.
+var Metrics generator.MetricsCollector
+
+// metricsCollector returns the collector to report the run to, if any
+func metricsCollector() generator.MetricsCollector {
+	if Metrics != nil {
+		return Metrics
+	}
+	if metricsPush != "" {
+		return generator.PushgatewayCollector{URL: metricsPush, Job: metricsJob}
+	}
+	return nil
+}
+
+// reportMetrics hands the metrics of a finished run to the collector. A
+// failing collector is only warned about so monitoring never breaks a report.
+func reportMetrics(format string, start time.Time, commits int, outputBytes int64) {
+	collector := metricsCollector()
+	if collector == nil {
+		return
+	}
+
+	err := collector.Collect(generator.RunMetrics{
+		Format:      format,
+		Duration:    time.Since(start),
+		Commits:     commits,
+		OutputBytes: outputBytes,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+}
+
+// filesSize returns the combined size of the given files, skipping any that
+// cannot be read
+func filesSize(paths ...string) int64 {
+	var total int64
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
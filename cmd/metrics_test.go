@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-report-generator/internal/generator"
+)
+
+func TestMetricsCallbackReceivesRunMetrics(t *testing.T) {
+	repo := newTestRepo(t, "First change", "Second change")
+	var runs []generator.RunMetrics
+	Metrics = generator.MetricsFunc(func(m generator.RunMetrics) error {
+		runs = append(runs, m)
+		return nil
+	})
+	t.Cleanup(func() { Metrics = nil })
+
+	output := filepath.Join(t.TempDir(), "report.json")
+	if err := execute(t, "--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--format", "json", "--output", output); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+
+	if len(runs) != 1 {
+		t.Fatalf("expected the metrics of one run, got %d", len(runs))
+	}
+	info, err := os.Stat(output)
+	if err != nil {
+		t.Fatalf("failed to stat report: %v", err)
+	}
+	m := runs[0]
+	if m.Format != "json" || m.Commits != 2 || m.OutputBytes != info.Size() || m.Duration <= 0 {
+		t.Errorf("unexpected metrics %+v for a %d byte report", m, info.Size())
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestPerAuthorOutputRedactsEmails(t *testing.T) {
+	dir := newTestRepo(t, "Jan change")
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	anna := object.Signature{Name: "Anna Nowak", Email: "anna@example.com", When: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)}
+	if _, err := worktree.Commit("Anna change", &git.CommitOptions{Author: &anna, AllowEmptyCommits: true}); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	out := t.TempDir()
+	configPath := filepath.Join(out, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"privacy": {"redact_emails": true}}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	stdout, stderr := captureOutput(t, func() {
+		err = execute(t, "--repo", dir, "--from", "2024-01-01", "--to", "2024-01-31", "--per-author",
+			"--author", testAuthor.Email, "--author", anna.Email,
+			"--config", configPath, "--output", filepath.Join(out, "report.pdf"))
+	})
+	if err != nil {
+		t.Fatalf("failed to generate per-author reports: %v", err)
+	}
+
+	output := stdout + stderr
+	for _, email := range []string{testAuthor.Email, anna.Email} {
+		if strings.Contains(output, email) {
+			t.Errorf("output shows %s despite redact_emails:\n%s", email, output)
+		}
+	}
+	for _, want := range []string{"Report for Jan Kowalski <j***@example.com>", "Report for Anna Nowak <a***@example.com>"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in the output:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git-report-generator/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// proofCmd writes the report together with the material needed to verify it
+// into a single directory or zip archive
+var proofCmd = &cobra.Command{
+	Use:   "proof",
+	Short: "Export a proof bundle with the PDF report, commit list and digests",
+	Long: `Export a proof bundle: a directory, or a zip archive when --output ends in
+.zip, containing the PDF report, the commit list as JSON, the commit set digest,
+the Merkle root of the commit hashes and the signatures of signed commits.`,
+	Args: cobra.NoArgs,
+	RunE: runProof,
+}
+
+func runProof(cmd *cobra.Command, args []string) error {
+	if outputPath == "-" {
+		return fmt.Errorf("--output - is not supported for proof bundles")
+	}
+
+	reportData, err := loadReportData()
+	if err != nil {
+		return err
+	}
+
+	if len(reportData.Commits) == 0 {
+		fmt.Printf("No commits found between %s and %s on branch %s\n",
+			reportData.DateFrom.Format("2006-01-02"), reportData.DateTo.Format("2006-01-02"), branch)
+		return nil
+	}
+
+	files, err := generator.ProofBundle(reportData)
+	if err != nil {
+		return fmt.Errorf("failed to build proof bundle: %w", err)
+	}
+
+	bundlePath := outputPath
+	if bundlePath == "" {
+		bundlePath = fmt.Sprintf("proof_%s", time.Now().Format("2006-01-02"))
+	}
+
+	if strings.EqualFold(filepath.Ext(bundlePath), ".zip") {
+		err = generator.WriteBundleZip(files, bundlePath)
+	} else {
+		err = generator.WriteBundleDir(files, bundlePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Proof bundle written: %s\n", bundlePath)
+	for _, file := range files {
+		fmt.Printf("   %s\n", file.Name)
+	}
+	return nil
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"git-report-generator/internal/config"
@@ -14,13 +17,70 @@ import (
 )
 
 var (
-	repoPath    string
-	dateFrom    string
-	dateTo      string
-	outputPath  string
-	configPath  string
-	authorEmail string
-	branch      string
+	repoPath     string
+	dateFrom     string
+	dateTo       string
+	outputPath   string
+	configPath   string
+	authorEmail  string
+	authorEmails []string
+	branch       string
+	branches     []string
+	mergeBase    string
+	exportSigs   bool
+	displayUTC   bool
+	repoName     string
+	format       string
+	withDiffs    bool
+	compact      bool
+	showStreaks  bool
+	requireClean bool
+	paths        []string
+	followRename bool
+	splitPages   int
+	authorName   string
+	authorRegex  bool
+	byteStats    bool
+	withStats    bool
+	profile      string
+	sortOrder    string
+	limit        int
+	authorLocal  bool
+	htmlTemplate string
+	includeEmpty bool
+	statAgainst  string
+	strictConfig bool
+	groupBy      string
+	noMailmap    bool
+	timezone     string
+	tzFromGit    bool
+	fileTypes    bool
+	noSummary    bool
+	withNotes    bool
+	dedupe       bool
+	perAuthor    bool
+	minCommits   int
+	pretty       bool
+	retryClone   int
+	verbose      bool
+	comparePrev  bool
+	lang         string
+	releaseNotes bool
+	metricsPush  string
+	metricsJob   string
+	fontSizeAuto bool
+	shaList      string
+	fromFile     string
+	toFile       string
+	keepClone    string
+	summaryFile  bool
+	scope        string
+	assumeYes    bool
+	maxCommits   int
+	revRange     string
+	diffContext  int
+	csvBOM       bool
+	templateFile string
 )
 
 var rootCmd = &cobra.Command{
@@ -36,120 +96,815 @@ Example usage:
 	RunE: runGenerate,
 }
 
+// generateCmd exposes the root generate action as an explicit subcommand,
+// so "git-report-generator gen ..." works like the bare root command
+var generateCmd = &cobra.Command{
+	Use:     "generate",
+	Aliases: []string{"gen"},
+	Short:   "Generate a report (same as running the root command)",
+	RunE:    runGenerate,
+}
+
 func Execute() error {
 	return rootCmd.Execute()
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&repoPath, "repo", "r", ".", "Path to the Git repository")
-	rootCmd.Flags().StringVarP(&dateFrom, "from", "f", "", "Start date (YYYY-MM-DD format)")
-	rootCmd.Flags().StringVarP(&dateTo, "to", "t", "", "End date (YYYY-MM-DD format)")
-	rootCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output PDF file path (default: report_YYYY-MM-DD.pdf)")
+	rootCmd.Flags().StringVarP(&repoPath, "repo", "r", ".", "Path to the Git repository, or a remote URL to clone")
+	rootCmd.Flags().StringVarP(&dateFrom, "from", "f", "", "Start date (YYYY-MM-DD or relative, e.g. 1m)")
+	rootCmd.Flags().StringVarP(&dateTo, "to", "t", "", "End date (YYYY-MM-DD, today or relative, e.g. 1d)")
+	rootCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path, or - for stdout (default: report_YYYY-MM-DD.<format>)")
 	rootCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to configuration file")
-	rootCmd.Flags().StringVarP(&authorEmail, "author", "a", "", "Author email to filter commits (if empty, uses git config user.email)")
-	rootCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch name to analyze (if empty, uses current branch)")
+	rootCmd.Flags().StringArrayVarP(&authorEmails, "author", "a", nil, "Author email to filter commits, repeatable to include several authors (if empty, uses git config user.email)")
+	rootCmd.Flags().StringArrayVarP(&branches, "branch", "b", nil, "Branch name to analyze, repeatable to merge several branches (if empty, uses current branch)")
+	rootCmd.Flags().StringVar(&mergeBase, "merge-base-with", "", "Only include commits since the merge base of the branch and this ref (PR-style report)")
+	rootCmd.Flags().BoolVar(&exportSigs, "export-signatures", false, "Write PGP signatures of signed commits to <output>.signatures.asc")
+	rootCmd.Flags().BoolVar(&displayUTC, "display-utc", false, "Render all dates in UTC (does not affect filtering)")
+	rootCmd.Flags().StringVar(&repoName, "repo-name", "", "Repository name displayed in the report (default: repository directory name)")
+	rootCmd.Flags().StringVar(&format, "format", "pdf", "Output format ("+strings.Join(generator.Formats(), ", ")+")")
+	rootCmd.Flags().BoolVar(&withDiffs, "include-diffs", false, "Embed the diff of commits changing at most stats.max_diff_lines lines")
+	rootCmd.Flags().BoolVar(&compact, "compact", false, "Use a compact one-line-per-commit layout without descriptions")
+	rootCmd.Flags().BoolVar(&showStreaks, "show-streaks", false, "Show active days and longest daily commit streak in the summary")
+	rootCmd.Flags().BoolVar(&requireClean, "require-clean", false, "Fail if the repository has uncommitted changes")
+	rootCmd.Flags().StringArrayVar(&paths, "path", nil, "Only include commits touching this path (repeatable)")
+	rootCmd.Flags().BoolVar(&followRename, "follow-renames", false, "Follow renames of files matched by --path into older history")
+	rootCmd.Flags().IntVar(&splitPages, "split-pages", 0, "Split the PDF into parts of at most N pages each (<output>-partN.pdf)")
+	rootCmd.Flags().StringVar(&authorName, "author-name", "", "Author name to filter commits (case-insensitive; combined with --author using AND)")
+	rootCmd.Flags().BoolVar(&authorRegex, "author-name-regex", false, "Treat --author-name as a regular expression")
+	rootCmd.Flags().BoolVar(&byteStats, "byte-stats", false, "Compute bytes added/removed per commit from blob sizes")
+	rootCmd.Flags().BoolVar(&withStats, "with-stats", false, "Collect inserted/deleted line counts and show net lines per commit")
+	rootCmd.Flags().StringVar(&profile, "profile", "", "Named profile from the configuration supplying default dates and filters")
+	rootCmd.Flags().StringVar(&sortOrder, "sort", "newest", "Commit order: newest or oldest first")
+	rootCmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of commits to include (0 for no limit)")
+	rootCmd.Flags().BoolVar(&authorLocal, "author-local-time", false, "Match the date range against each commit's date in its author's own timezone")
+	rootCmd.Flags().StringVar(&htmlTemplate, "html-template", "", "html/template file used instead of the built-in HTML template")
+	rootCmd.Flags().BoolVar(&includeEmpty, "include-empty-days", false, "Add calendar rows for days of the range without commits")
+	rootCmd.Flags().StringVar(&statAgainst, "stat-against", "", "Compute line/byte stats against this fixed ref instead of each commit's parent")
+	rootCmd.Flags().BoolVar(&strictConfig, "strict-config", false, "Reject unknown keys in the configuration file")
+	rootCmd.Flags().StringVar(&groupBy, "group-by", "", "Section the report by author, day or branch")
+	rootCmd.Flags().BoolVar(&noMailmap, "no-mailmap", false, "Do not map author identities through the repository's .mailmap")
+	rootCmd.Flags().StringVar(&timezone, "timezone", "", "IANA timezone the date range is interpreted in, e.g. Europe/Warsaw (default local zone)")
+	rootCmd.Flags().BoolVar(&tzFromGit, "tz-from-git-config", false, "Default --timezone to gitreport.timezone from git config before the local zone")
+	rootCmd.Flags().BoolVar(&fileTypes, "show-filetype-stats", false, "Show inserted/deleted lines per file extension in the summary")
+	rootCmd.Flags().BoolVar(&noSummary, "no-summary", false, "Omit the summary block below the commit table")
+	rootCmd.Flags().BoolVar(&withNotes, "include-notes", false, "Show git notes (refs/notes/commits) attached to commits")
+	rootCmd.Flags().BoolVar(&dedupe, "dedupe-by-message", false, "Collapse commits with identical messages (e.g. cherry-picks), keeping the earliest")
+	rootCmd.Flags().BoolVar(&perAuthor, "per-author", false, "Write a separate report per author (<output>-<email>.<format>) plus an index.html")
+	rootCmd.Flags().IntVar(&minCommits, "min-commits", 0, "With --group-by author or --per-author, omit authors with fewer commits")
+	rootCmd.Flags().BoolVar(&pretty, "pretty", false, "Indent JSON output (default: compact on stdout, indented in files)")
+	rootCmd.Flags().IntVar(&retryClone, "retry-clone", 0, "Retry cloning a remote --repo URL this many times on network errors, with exponential backoff")
+	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Log progress details such as failed clone attempts to stderr")
+	rootCmd.Flags().BoolVar(&comparePrev, "compare-previous", false, "Show changes in commits and lines against the preceding period of equal length")
+	rootCmd.Flags().StringVar(&lang, "lang", "", "Language of month and weekday names in dates: pl or en (default from config, pl)")
+	rootCmd.Flags().BoolVar(&releaseNotes, "release-notes", false, "Section the report by release, between consecutive tags (e.g. v1.1.0 → v1.2.0)")
+	rootCmd.Flags().StringVar(&metricsPush, "metrics-push", "", "Push generation duration, commit count and output size to this Prometheus Pushgateway URL")
+	rootCmd.Flags().StringVar(&metricsJob, "metrics-job", "git_report_generator", "Job name the metrics are pushed under with --metrics-push")
+	rootCmd.Flags().BoolVar(&fontSizeAuto, "font-size-auto", false, "Shrink the commit table font until the PDF fits pdf.max_pages pages")
+	rootCmd.Flags().StringVar(&shaList, "sha-list", "", "Report exactly the commits listed in this file (one hash per line), in list order, ignoring date and author filters")
+	rootCmd.Flags().StringVar(&fromFile, "from-file", "", "Read the start date from this file (ignored when --from is given)")
+	rootCmd.Flags().StringVar(&toFile, "to-file", "", "Read the end date from this file (ignored when --to is given)")
+	rootCmd.Flags().StringVar(&keepClone, "keep-clone", "", "Keep the clone of a remote --repo URL in this directory and fetch into it on later runs")
+	rootCmd.Flags().BoolVar(&summaryFile, "summary-sidecar", false, "Also write aggregate metrics of the report to <output>.summary.json")
+	rootCmd.Flags().StringVar(&scope, "scope", "branch", "Commits to consider: branch (reachable from the branch tip) or repo (on any branch or tag)")
+	rootCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation before generating a report with more than pdf.warn_commits commits")
+	rootCmd.Flags().IntVar(&maxCommits, "max-commits", 0, "Fail when the report would include more commits than this (0 for no cap)")
+	rootCmd.Flags().StringVar(&revRange, "range", "", "Report the commits of a git revision range, e.g. main~10..main (--from/--to become optional)")
+	rootCmd.Flags().IntVar(&diffContext, "diff-context", 3, "Number of unchanged lines shown around each change in --include-diffs diffs")
+	rootCmd.Flags().BoolVar(&csvBOM, "csv-bom", false, "Start CSV output with a UTF-8 byte order mark so that Excel reads Polish characters correctly")
+	rootCmd.Flags().StringVar(&templateFile, "template-file", "", "File with the header template, overriding header.template")
 
-	rootCmd.MarkFlagRequired("from")
-	rootCmd.MarkFlagRequired("to")
+	// Share the root flags with the subcommands
+	generateCmd.Flags().AddFlagSet(rootCmd.Flags())
+	listCmd.Flags().AddFlagSet(rootCmd.Flags())
+	proofCmd.Flags().AddFlagSet(rootCmd.Flags())
+	auditCmd.Flags().AddFlagSet(rootCmd.Flags())
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("repo"))
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("retry-clone"))
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("verbose"))
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("keep-clone"))
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("author"))
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("config"))
+	verifyChecksumCmd.Flags().AddFlag(rootCmd.Flags().Lookup("strict-config"))
+	inspectCmd.Flags().AddFlag(rootCmd.Flags().Lookup("repo"))
+	rootCmd.AddCommand(generateCmd, listCmd, auditCmd, proofCmd, verifyChecksumCmd, inspectCmd, fontsCmd)
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
-	// Validate and parse dates
-	fromDate, err := time.Parse("2006-01-02", dateFrom)
+	start := time.Now()
+
+	// Without --format, a known output extension (e.g. report.md) picks it
+	if !cmd.Flags().Changed("format") && outputPath != "" && outputPath != "-" {
+		ext := strings.TrimPrefix(filepath.Ext(outputPath), ".")
+		if _, err := generator.Lookup(ext); err == nil && ext != "" {
+			format = strings.ToLower(ext)
+		}
+	}
+	reportWriter, err := generator.Lookup(format)
 	if err != nil {
-		return fmt.Errorf("invalid from date format. Use YYYY-MM-DD: %w", err)
+		return err
 	}
 
-	toDate, err := time.Parse("2006-01-02", dateTo)
+	if splitPages < 0 {
+		return fmt.Errorf("split pages cannot be negative")
+	}
+	if splitPages > 0 && reportWriter.Format() != "pdf" {
+		return fmt.Errorf("--split-pages is only supported for the pdf format")
+	}
+
+	if perAuthor && splitPages > 0 {
+		return fmt.Errorf("--per-author cannot be combined with --split-pages")
+	}
+
+	// Keep stdout free of status messages when streaming the report to it
+	toStdout := outputPath == "-"
+	if toStdout && (splitPages > 0 || exportSigs || perAuthor || summaryFile) {
+		return fmt.Errorf("--output - is not supported with --split-pages, --export-signatures, --per-author or --summary-sidecar")
+	}
+	status := os.Stdout
+	if toStdout {
+		status = os.Stderr
+	}
+
+	reportData, err := loadReportData()
 	if err != nil {
-		return fmt.Errorf("invalid to date format. Use YYYY-MM-DD: %w", err)
+		return err
+	}
+	commits := reportData.Commits
+
+	// Compact JSON pipes better, indented JSON reads better in a saved file
+	reportData.PrettyJSON = !toStdout
+	if cmd.Flags().Changed("pretty") {
+		reportData.PrettyJSON = pretty
+	}
+	reportData.CSVBOM = csvBOM
+
+	if minCommits > 0 && !perAuthor && reportData.Config.PDF.GroupBy != config.GroupByAuthor {
+		return fmt.Errorf("--min-commits requires --group-by author or --per-author")
+	}
+
+	labelEmails := reportData.AuthorEmails
+	if reportData.Config.Privacy.RedactEmails {
+		labelEmails = make([]string, len(reportData.AuthorEmails))
+		for i, email := range reportData.AuthorEmails {
+			labelEmails[i] = generator.RedactEmail(email)
+		}
+	}
+	authorLabel := generator.AuthorLabel(authorName, strings.Join(labelEmails, ", "))
+	if authorLabel == "" {
+		authorLabel = "all authors"
+	}
+
+	if len(commits) == 0 {
+		fmt.Fprintf(status, "No commits found for author %s between %s and %s on branch %s\n",
+			authorLabel,
+			reportData.DateFrom.Format("2006-01-02"), reportData.DateTo.Format("2006-01-02"), branch)
+		// Markdown is embedded by other tooling, which always expects a file
+		if reportWriter.Format() != "md" {
+			return nil
+		}
+	}
+
+	if err := confirmLargeReport(len(commits), reportData.Config.PDF.WarnCommits); err != nil {
+		return err
+	}
+
+	if toStdout {
+		out := &generator.CountingWriter{W: os.Stdout}
+		if err := reportWriter.Write(reportData, out); err != nil {
+			return fmt.Errorf("failed to generate %s report: %w", reportWriter.Format(), err)
+		}
+		reportMetrics(reportWriter.Format(), start, len(commits), out.N)
+		return nil
+	}
+
+	// Generate output filename if not provided
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("report_%s.%s", time.Now().Format("2006-01-02"), reportWriter.Format())
+	}
+
+	// Ensure output directory exists
+	outputDir := filepath.Dir(outputPath)
+	if outputDir != "." {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	var indexEntries []generator.IndexEntry
+	switch {
+	case perAuthor:
+		indexEntries, err = writePerAuthorReports(reportWriter, reportData, outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to generate per-author reports: %w", err)
+		}
+	case splitPages > 0:
+		indexEntries, err = writeSplitReport(reportData, outputPath, splitPages)
+		if err != nil {
+			return fmt.Errorf("failed to generate split PDF report: %w", err)
+		}
+	default:
+		if err := writeReport(reportWriter, reportData, outputPath); err != nil {
+			return fmt.Errorf("failed to generate %s report: %w", reportWriter.Format(), err)
+		}
+		fmt.Printf("✅ Report generated successfully: %s\n", outputPath)
 	}
 
-	if fromDate.After(toDate) {
-		return fmt.Errorf("from date cannot be after to date")
+	// Link several generated files from a landing page
+	if len(indexEntries) > 1 {
+		indexPath := filepath.Join(filepath.Dir(outputPath), "index.html")
+		if err := generator.WriteIndex(indexPath, indexEntries); err != nil {
+			return err
+		}
+		fmt.Printf("🗂  Index of %d reports written: %s\n", len(indexEntries), indexPath)
+	}
+	fmt.Printf("📊 Found %d commits for %s between %s and %s\n",
+		len(commits), authorLabel,
+		reportData.DateFrom.Format("2006-01-02"), reportData.DateTo.Format("2006-01-02"))
+	if reportData.Collapsed > 0 {
+		fmt.Printf("🔁 Collapsed %d commits with duplicate messages\n", reportData.Collapsed)
+	}
+	if reportData.OmittedAuthors > 0 {
+		fmt.Printf("🙈 %d authors omitted with fewer than %d commits\n", reportData.OmittedAuthors, minCommits)
+	}
+
+	// Export aggregate metrics sidecar
+	if summaryFile {
+		summaryPath := generator.SummaryPath(outputPath)
+		if err := generator.WriteSummary(reportData, summaryPath); err != nil {
+			return err
+		}
+		fmt.Printf("📈 Summary written: %s\n", summaryPath)
+	}
+
+	// Export commit signatures sidecar
+	if exportSigs {
+		sigPath := outputPath + ".signatures.asc"
+		count, err := generator.WriteSignatures(commits, sigPath)
+		if err != nil {
+			return fmt.Errorf("failed to export signatures: %w", err)
+		}
+		fmt.Printf("🔏 Exported %d commit signatures: %s\n", count, sigPath)
+	}
+
+	written := []string{outputPath}
+	if len(indexEntries) > 0 {
+		written = written[:0]
+		for _, entry := range indexEntries {
+			written = append(written, entry.Path)
+		}
+	}
+	reportMetrics(reportWriter.Format(), start, len(commits), filesSize(written...))
+
+	return nil
+}
+
+// loadReportData resolves the configuration, dates and filters given on the
+// command line and queries the matching commits
+func loadReportData() (*generator.ReportData, error) {
+	if sortOrder != "newest" && sortOrder != "oldest" {
+		return nil, fmt.Errorf("invalid sort order %q (use newest or oldest)", sortOrder)
+	}
+	if scope != "branch" && scope != "repo" {
+		return nil, fmt.Errorf("invalid scope %q (use branch or repo)", scope)
+	}
+	if scope == "repo" && (len(branches) > 1 || mergeBase != "") {
+		return nil, fmt.Errorf("--scope repo cannot be combined with several --branch flags or --merge-base-with")
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+	if diffContext < 0 {
+		return nil, fmt.Errorf("diff context cannot be negative")
+	}
+	if maxCommits < 0 {
+		return nil, fmt.Errorf("max commits cannot be negative")
+	}
+	if minCommits < 0 {
+		return nil, fmt.Errorf("min commits cannot be negative")
 	}
 
 	// Load configuration
-	cfg, err := config.Load(configPath)
+	load := config.Load
+	if strictConfig {
+		load = config.LoadStrict
+	}
+	cfg, err := load(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+	if displayUTC {
+		cfg.PDF.DisplayUTC = true
+	}
+	if lang != "" {
+		if lang != "pl" && lang != "en" {
+			return nil, fmt.Errorf("invalid language %q (use pl or en)", lang)
+		}
+		cfg.Lang = lang
+	}
+	if compact {
+		cfg.PDF.Compact = true
+	}
+	if fontSizeAuto {
+		if cfg.PDF.MaxPages == 0 {
+			return nil, fmt.Errorf("--font-size-auto requires pdf.max_pages to be set in the configuration")
+		}
+		cfg.PDF.FontSizeAuto = true
+	}
+	if includeEmpty {
+		cfg.PDF.IncludeEmptyDays = true
+	}
+	if htmlTemplate != "" {
+		cfg.HTML.TemplateFile = htmlTemplate
+	}
+	if templateFile != "" {
+		cfg.Header.TemplateFile = templateFile
+	}
+	if path := cfg.Header.TemplateFile; path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header template file: %w", err)
+		}
+		if strings.TrimSpace(string(content)) == "" {
+			return nil, fmt.Errorf("header template file %s is empty", path)
+		}
+		cfg.Header.Template = string(content)
+	}
+	if groupBy != "" {
+		if groupBy != config.GroupByAuthor && groupBy != config.GroupByDay && groupBy != config.GroupByBranch {
+			return nil, fmt.Errorf("invalid group by %q (use author, day or branch)", groupBy)
+		}
+		cfg.PDF.GroupBy = groupBy
+	}
+	if releaseNotes {
+		if groupBy != "" {
+			return nil, fmt.Errorf("--release-notes cannot be combined with --group-by")
+		}
+		cfg.PDF.GroupBy = config.GroupByRelease
+	}
+	if noSummary {
+		cfg.PDF.ShowSummary = false
+	}
+	if showStreaks {
+		cfg.PDF.ShowStreaks = true
+	}
+	if withStats {
+		cfg.Stats.WithStats = true
+	}
+	if byteStats {
+		cfg.Stats.ByteStats = true
+	}
+	if fileTypes {
+		cfg.Stats.FileTypeStats = true
+	}
+	if statAgainst != "" {
+		cfg.Stats.StatAgainst = statAgainst
+	}
+
+	// Dates written by an upstream pipeline step, unless given directly
+	if dateFrom == "" && fromFile != "" {
+		dateFrom, err = readDateFile(fromFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dateTo == "" && toFile != "" {
+		dateTo, err = readDateFile(toFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Fill unset filters from the selected profile
+	if profile != "" {
+		p, ok := cfg.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found in configuration", profile)
+		}
+		applyProfile(p)
+	}
+
+	// A listed commit set comes from --sha-list or from a report being verified
+	listed := verifiedSHAs
+	if shaList != "" {
+		listed, err = readSHAList(shaList)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A revision range replaces the branch and, unless given, the dates
+	var rangeFrom, rangeTo string
+	if revRange != "" {
+		rangeFrom, rangeTo, err = git.ParseRange(revRange)
+		if err != nil {
+			return nil, err
+		}
+		if len(listed) > 0 || len(branches) > 1 || mergeBase != "" || scope == "repo" || comparePrev {
+			return nil, fmt.Errorf("--range cannot be combined with --sha-list, several --branch flags, --merge-base-with, --scope repo or --compare-previous")
+		}
+	}
+	dateless := len(listed) > 0 || (revRange != "" && dateFrom == "" && dateTo == "")
+
+	// Validate and parse dates
+	if !dateless && (dateFrom == "" || dateTo == "") {
+		return nil, fmt.Errorf("both --from and --to are required (directly or via --profile)")
+	}
+	if len(listed) > 0 && comparePrev {
+		return nil, fmt.Errorf("--sha-list cannot be combined with --compare-previous")
 	}
 
-	// Get absolute path to repository
-	absRepoPath, err := filepath.Abs(repoPath)
+	// Initialize Git service, cloning a remote repository first
+	gitService, cleanup, err := openRepository()
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path for repository: %w", err)
+		return nil, err
 	}
+	defer cleanup()
 
-	// Initialize Git service
-	gitService, err := git.NewService(absRepoPath)
+	// Resolve the zone the date range is interpreted in
+	loc, err := resolveTimezone(gitService)
 	if err != nil {
-		return fmt.Errorf("failed to initialize Git service: %w", err)
+		return nil, err
 	}
 
-	// Get author email if not provided
-	if authorEmail == "" {
+	// A listed commit set or range spans the days of its own commits instead
+	var fromDate, toDate time.Time
+	if !dateless {
+		now := time.Now().In(loc)
+		fromDate, err = parseDate(dateFrom, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from date: %w", err)
+		}
+
+		toDate, err = parseDate(dateTo, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to date: %w", err)
+		}
+
+		if fromDate.After(toDate) {
+			return nil, fmt.Errorf("from date cannot be after to date")
+		}
+	}
+
+	// Ensure the report reflects committed state only
+	if requireClean {
+		dirty, err := gitService.GetDirtyPaths()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check working tree status: %w", err)
+		}
+		if len(dirty) > 0 {
+			return nil, fmt.Errorf("working tree has uncommitted changes: %s", strings.Join(dirty, ", "))
+		}
+	}
+
+	// Build the author name filter
+	var namePattern *regexp.Regexp
+	exactName := authorName
+	if authorRegex {
+		if authorName == "" {
+			return nil, fmt.Errorf("--author-name-regex requires --author-name")
+		}
+		namePattern, err = regexp.Compile("(?i)" + authorName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid author name pattern: %w", err)
+		}
+		exactName = ""
+	}
+
+	// Get author email if not provided and not filtering by name alone.
+	// Reports grouped or split by author cover the whole team instead.
+	if len(authorEmails) > 0 {
+		authorEmail = authorEmails[0]
+	}
+	if authorEmail == "" && authorName == "" && len(listed) == 0 && cfg.PDF.GroupBy != config.GroupByAuthor && !perAuthor {
 		authorEmail, err = gitService.GetUserEmail()
 		if err != nil {
-			return fmt.Errorf("failed to get user email from git config: %w", err)
+			return nil, fmt.Errorf("failed to get user email from git config: %w", err)
 		}
 	}
 
+	// Several --author flags match the commits of any of the emails
+	var reportEmails []string
+	if len(authorEmails) > 1 {
+		reportEmails = authorEmails
+	} else if authorEmail != "" {
+		reportEmails = []string{authorEmail}
+	}
+
 	// Get branch name if not provided
-	if branch == "" {
+	if len(branches) > 0 {
+		branch = branches[0]
+	}
+	if branch == "" && revRange == "" {
 		branch, err = gitService.GetCurrentBranch()
 		if err != nil {
-			return fmt.Errorf("failed to get current branch: %w", err)
+			return nil, fmt.Errorf("failed to get current branch: %w", err)
 		}
 	}
 
-	// Get repository name
-	repoName := gitService.GetRepositoryName()
+	// Record the exact repository state the report is generated against
+	var headHash string
+	if revRange != "" {
+		headHash, err = gitService.ResolveRevision(rangeTo)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		headHash, err = gitService.GetBranchHash(branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch tip hash: %w", err)
+		}
+	}
 
-	// Get commits for the specified period and author
-	commits, err := gitService.GetCommits(fromDate, toDate, authorEmail, branch)
+	// Pull request links need the remote the repository was cloned from
+	remoteURL, err := gitService.GetRemoteURL()
 	if err != nil {
-		return fmt.Errorf("failed to get commits: %w", err)
+		return nil, err
 	}
 
-	if len(commits) == 0 {
-		fmt.Printf("No commits found for author %s between %s and %s on branch %s\n",
-			authorEmail, dateFrom, dateTo, branch)
-		return nil
+	// Get repository name, preferring the flag and then the configured override
+	if repoName == "" {
+		repoName = cfg.Header.RepositoryName
+	}
+	if repoName == "" {
+		repoName = gitService.GetRepositoryName()
 	}
 
-	// Generate output filename if not provided
-	if outputPath == "" {
-		outputPath = fmt.Sprintf("report_%s.pdf", time.Now().Format("2006-01-02"))
+	var subjectPrefix *regexp.Regexp
+	if cfg.CommitParsing.StripSubjectPrefix != "" {
+		subjectPrefix, err = regexp.Compile(cfg.CommitParsing.StripSubjectPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject prefix pattern: %w", err)
+		}
 	}
 
-	// Ensure output directory exists
-	outputDir := filepath.Dir(outputPath)
-	if outputDir != "." {
-		if err := os.MkdirAll(outputDir, 0755); err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+	// Get commits for the specified period and author
+	query := git.CommitQuery{
+		From:              fromDate,
+		To:                toDate,
+		AuthorLocalTime:   authorLocal,
+		AuthorEmails:      reportEmails,
+		AuthorName:        exactName,
+		AuthorNamePattern: namePattern,
+		Branch:            branch,
+		AllRefs:           scope == "repo",
+		MergeBaseWith:     mergeBase,
+		RangeFrom:         rangeFrom,
+		RangeTo:           rangeTo,
+		Paths:             paths,
+		FollowRenames:     followRename,
+		IncludeDiffs:      withDiffs,
+		DiffContext:       diffContext,
+		MaxDiffLines:      cfg.Stats.MaxDiffLines,
+		WithStats:         cfg.Stats.WithStats || cfg.Stats.FileTypeStats,
+		ByteStats:         cfg.Stats.ByteStats,
+		StatAgainst:       cfg.Stats.StatAgainst,
+		Oldest:            sortOrder == "oldest",
+		Limit:             limit,
+		IgnoreMailmap:     noMailmap,
+		SubjectPrefix:     subjectPrefix,
+		KeepSignOffs:      cfg.CommitParsing.KeepSignOffs,
+		EmptyMessage:      cfg.CommitParsing.EmptyMessage,
+		IncludeNotes:      withNotes,
+	}
+	if comparePrev {
+		// Line totals of both periods are compared
+		query.WithStats = true
+	}
+	var commits []*git.Commit
+	reportBranches := []string{branch}
+	if revRange != "" {
+		reportBranches = []string{revRange}
+	}
+	tips := []string{headHash}
+	var listedHashes []string
+	if len(listed) > 0 {
+		commits, err = gitService.GetCommitsByHashes(listed, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get listed commits: %w", err)
+		}
+		fromDate, toDate = commitSpan(commits, loc)
+		for _, commit := range commits {
+			listedHashes = append(listedHashes, commit.Hash)
+		}
+	} else {
+		commits, err = gitService.GetCommits(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commits: %w", err)
+		}
+		if dateless && len(commits) > 0 {
+			fromDate, toDate = commitSpan(commits, loc)
+		}
+
+		// Merge the commits of further --branch flags, deduplicated by hash
+		if len(branches) > 1 {
+			var extraTips []string
+			commits, extraTips, err = mergeBranchCommits(gitService, query, commits, branches[1:])
+			if err != nil {
+				return nil, err
+			}
+			reportBranches = branches
+			tips = append(tips, extraTips...)
 		}
 	}
 
-	// Generate PDF report
+	// Prove every reported commit descends from one of the branch tips; with
+	// the repo scope commits on other refs are expected, and listed commits
+	// may come from any branch
+	chained := scope == "branch" && len(listed) == 0
+	var unreachable []*git.Commit
+	if chained {
+		unreachable = commits
+	} else {
+		tips = nil
+	}
+	for _, tip := range tips {
+		unreachable, err = gitService.VerifyChain(unreachable, tip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commit chain: %w", err)
+		}
+	}
+	if len(unreachable) > 0 {
+		shas := make([]string, len(unreachable))
+		for i, commit := range unreachable {
+			shas[i] = commit.SHA
+		}
+		return nil, fmt.Errorf("commits not reachable from branch tip %s: %s", headHash[:7], strings.Join(shas, ", "))
+	}
+	if maxCommits > 0 && len(commits) > maxCommits {
+		return nil, fmt.Errorf("the report would include %d commits, more than --max-commits %d", len(commits), maxCommits)
+	}
+
 	reportData := &generator.ReportData{
 		Config:         cfg,
 		RepositoryName: repoName,
-		BranchName:     branch,
+		BranchName:     strings.Join(reportBranches, ", "),
+		Branches:       reportBranches,
+		HeadHash:       headHash,
 		AuthorEmail:    authorEmail,
+		AuthorEmails:   reportEmails,
+		AuthorName:     authorName,
 		DateFrom:       fromDate,
 		DateTo:         toDate,
 		Commits:        commits,
+		ChainVerified:  chained,
+		AllRefs:        scope == "repo",
+		RemoteURL:      remoteURL,
+		ProofParams:    proofParams(fromDate, toDate, loc, listedHashes),
 	}
 
-	pdfGenerator := generator.NewPDFGenerator()
-	if err := pdfGenerator.Generate(reportData, outputPath); err != nil {
-		return fmt.Errorf("failed to generate PDF report: %w", err)
+	// Collapse cherry-picked duplicates before grouping
+	if dedupe {
+		reportData.Commits, reportData.Collapsed = generator.DedupeByMessage(reportData.Commits)
 	}
 
-	fmt.Printf("✅ Report generated successfully: %s\n", outputPath)
-	fmt.Printf("📊 Found %d commits for %s between %s and %s\n",
-		len(commits), authorEmail, dateFrom, dateTo)
+	// Drop authors below the threshold of team reports
+	if minCommits > 0 {
+		reportData.Commits, reportData.OmittedAuthors = generator.DropSparseAuthors(reportData.Commits, minCommits)
+		reportData.MinCommits = minCommits
+	}
+
+	// Tags bound the release sections
+	if cfg.PDF.GroupBy == config.GroupByRelease {
+		reportData.Releases, err = gitService.GetTags()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Query the same filters for the preceding period of equal length
+	if comparePrev {
+		previous := query
+		previous.From, previous.To = generator.PreviousPeriod(fromDate, toDate)
+		previousCommits, err := gitService.GetCommits(previous)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commits of the previous period: %w", err)
+		}
+		if dedupe {
+			previousCommits, _ = generator.DedupeByMessage(previousCommits)
+		}
+		if minCommits > 0 {
+			previousCommits, _ = generator.DropSparseAuthors(previousCommits, minCommits)
+		}
+		totals := generator.Totals(previousCommits, previous.From, previous.To)
+		reportData.Previous = &totals
+	}
+
+	// Order the commits by report section when grouping
+	reportData.Commits = generator.GroupCommits(reportData)
+
+	return reportData, nil
+}
+
+// writeReport renders the report with the given writer into the output file
+func writeReport(w generator.ReportWriter, data *generator.ReportData, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := w.Write(data, file); err != nil {
+		// Do not leave a truncated report behind
+		file.Close()
+		os.Remove(outputPath)
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close output file: %w", err)
+	}
 
 	return nil
 }
+
+// writeSplitReport writes the PDF report as several parts of at most maxPages
+// pages each, every part with its own header
+func writeSplitReport(data *generator.ReportData, outputPath string, maxPages int) ([]generator.IndexEntry, error) {
+	pdfGenerator := generator.NewPDFGenerator()
+	parts, err := pdfGenerator.SplitByPages(data, maxPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []generator.IndexEntry
+	for i, commits := range parts {
+		partData := *data
+		partData.Commits = commits
+		partPath := generator.PartPath(outputPath, i+1)
+
+		if err := writeReport(pdfGenerator, &partData, partPath); err != nil {
+			return nil, fmt.Errorf("failed to write part %d: %w", i+1, err)
+		}
+		fmt.Printf("✅ Report part %d/%d generated successfully: %s\n", i+1, len(parts), partPath)
+		entries = append(entries, generator.IndexEntry{
+			Path:    partPath,
+			Label:   fmt.Sprintf("Część %d/%d", i+1, len(parts)),
+			Commits: len(commits),
+		})
+	}
+
+	return entries, nil
+}
+
+// writePerAuthorReports writes a separate report for every author, with the
+// authors ordered by commit count
+func writePerAuthorReports(w generator.ReportWriter, data *generator.ReportData, outputPath string) ([]generator.IndexEntry, error) {
+	var entries []generator.IndexEntry
+	for _, commits := range generator.SplitByAuthor(data.Commits) {
+		author := commits[0]
+		authorData := *data
+		authorData.Commits = commits
+		authorData.AuthorEmail = author.AuthorEmail
+		authorData.AuthorName = author.Author
+
+		// Each file verifies against its own author's commits
+		authorData.ProofParams = url.Values{}
+		for key, values := range data.ProofParams {
+			authorData.ProofParams[key] = values
+		}
+		authorData.ProofParams.Set("author", author.AuthorEmail)
+
+		// Redacted reports are named by a digest rather than the address, and
+		// the index and progress output only show the redacted address
+		email, slug := author.AuthorEmail, author.AuthorEmail
+		if data.Config.Privacy.RedactEmails {
+			email = generator.RedactEmail(email)
+			slug = generator.EmailDigest(slug)[:12]
+		}
+		label := generator.AuthorLabel(author.Author, email)
+		authorPath := generator.AuthorPath(outputPath, slug)
+		if err := writeReport(w, &authorData, authorPath); err != nil {
+			return nil, fmt.Errorf("failed to write report for %s: %w", label, err)
+		}
+		fmt.Printf("✅ Report for %s generated successfully: %s\n", label, authorPath)
+
+		entries = append(entries, generator.IndexEntry{
+			Path:    authorPath,
+			Label:   label,
+			Commits: len(commits),
+		})
+	}
+
+	return entries, nil
+}
+
+// applyProfile fills dates and filters that were not given on the command line
+func applyProfile(p config.ProfileConfig) {
+	if dateFrom == "" {
+		dateFrom = p.From
+	}
+	if dateTo == "" {
+		dateTo = p.To
+	}
+	if dateTo == "" && dateFrom != "" {
+		dateTo = "today"
+	}
+	if authorEmail == "" {
+		authorEmail = p.Author
+	}
+	if branch == "" {
+		branch = p.Branch
+	}
+}
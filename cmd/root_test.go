@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"git-report-generator/internal/config"
+)
+
+func TestGenAliasDispatchesToGenerate(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"gen", "--from", "2024-01-01"})
+	if err != nil {
+		t.Fatalf("failed to find the gen command: %v", err)
+	}
+	if cmd != generateCmd {
+		t.Fatalf("gen resolved to %q, want %q", cmd.Name(), generateCmd.Name())
+	}
+	if reflect.ValueOf(cmd.RunE).Pointer() != reflect.ValueOf(rootCmd.RunE).Pointer() {
+		t.Error("gen does not run the root generate action")
+	}
+	if cmd.Flags().Lookup("from") == nil {
+		t.Error("gen does not accept the root command flags")
+	}
+}
+
+func TestProfileSuppliesDefaultsFlagsOverride(t *testing.T) {
+	saved := []string{dateFrom, dateTo, authorEmail, branch}
+	t.Cleanup(func() {
+		dateFrom, dateTo, authorEmail, branch = saved[0], saved[1], saved[2], saved[3]
+	})
+
+	p := config.ProfileConfig{From: "1m", Author: "me@example.com", Branch: "main"}
+
+	// Nothing given on the command line
+	dateFrom, dateTo, authorEmail, branch = "", "", "", ""
+	applyProfile(p)
+	if dateFrom != "1m" || dateTo != "today" || authorEmail != "me@example.com" || branch != "main" {
+		t.Errorf("profile defaults = %q %q %q %q, want 1m today me@example.com main",
+			dateFrom, dateTo, authorEmail, branch)
+	}
+
+	// Flags win over the profile
+	dateFrom, dateTo, authorEmail, branch = "2024-01-01", "2024-01-31", "other@example.com", "develop"
+	applyProfile(p)
+	if dateFrom != "2024-01-01" || dateTo != "2024-01-31" || authorEmail != "other@example.com" || branch != "develop" {
+		t.Errorf("overridden values = %q %q %q %q, want the flag values", dateFrom, dateTo, authorEmail, branch)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+// shaPattern matches a full or abbreviated commit hash
+var shaPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// readSHAList reads the commit hashes listed one per line in the file.
+// Blank lines and lines starting with # are skipped.
+func readSHAList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SHA list: %w", err)
+	}
+	defer file.Close()
+
+	var hashes []string
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if !shaPattern.MatchString(text) {
+			return nil, fmt.Errorf("invalid commit hash %q on line %d of %s", text, line, path)
+		}
+		hashes = append(hashes, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read SHA list: %w", err)
+	}
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("SHA list %s contains no commit hashes", path)
+	}
+
+	return hashes, nil
+}
+
+// commitSpan returns the days of the earliest and the latest commit, used as
+// the report period of a listed commit set
+func commitSpan(commits []*git.Commit, loc *time.Location) (from, to time.Time) {
+	for i, commit := range commits {
+		date := commit.Date.In(loc)
+		if i == 0 || date.Before(from) {
+			from = date
+		}
+		if i == 0 || date.After(to) {
+			to = date
+		}
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+	return from, to
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestReadSHAList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shas.txt")
+	if err := os.WriteFile(path, []byte("# exported from the tracker\nabc1234\n\n  0123456789abcdef0123456789abcdef01234567  \n"), 0644); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+	hashes, err := readSHAList(path)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	if want := []string{"abc1234", "0123456789abcdef0123456789abcdef01234567"}; !reflect.DeepEqual(hashes, want) {
+		t.Errorf("hashes = %q, want %q", hashes, want)
+	}
+
+	if err := os.WriteFile(path, []byte("abc1234\nnot-a-hash\n"), 0644); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+	if _, err := readSHAList(path); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an invalid hash error on line 2, got %v", err)
+	}
+}
+
+func TestSHAListReportsListedCommitsInOrder(t *testing.T) {
+	dir := newTestRepo(t, "First change", "Second change", "Third change")
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	log, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	hashes := map[string]string{}
+	log.ForEach(func(c *object.Commit) error {
+		hashes[c.Message] = c.Hash.String()
+		return nil
+	})
+
+	list := filepath.Join(t.TempDir(), "shas.txt")
+	if err := os.WriteFile(list, []byte(hashes["First change"][:8]+"\n"+hashes["Third change"]+"\n"), 0644); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+	output := filepath.Join(t.TempDir(), "report.json")
+	if err := execute(t, "--repo", dir, "--sha-list", list, "--author", "nobody@example.com",
+		"--format", "json", "--output", output); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var commits []struct {
+		Subject string `json:"subject"`
+	}
+	if err := json.Unmarshal(content, &commits); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+	var got []string
+	for _, commit := range commits {
+		got = append(got, commit.Subject)
+	}
+	if want := []string{"First change", "Third change"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subjects = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPDFStreamedToStdout(t *testing.T) {
+	repo := newTestRepo(t, "First change")
+
+	var err error
+	stdout, stderr := captureOutput(t, func() {
+		err = execute(t, "--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+			"--author", testAuthor.Email, "--output", "-")
+	})
+	if err != nil {
+		t.Fatalf("failed to stream report: %v", err)
+	}
+	if !strings.HasPrefix(stdout, "%PDF-") || !strings.HasSuffix(strings.TrimSpace(stdout), "%%EOF") {
+		t.Errorf("stdout is not a PDF document: %.40q", stdout)
+	}
+	if strings.Contains(stdout, "✅") || strings.Contains(stderr, "%PDF-") {
+		t.Error("status messages and the report are mixed up")
+	}
+
+	err = execute(t, "--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--output", "-", "--split-pages", "1")
+	if err == nil || !strings.Contains(err.Error(), "--split-pages") {
+		t.Errorf("expected --split-pages to be rejected on stdout, got %v", err)
+	}
+}
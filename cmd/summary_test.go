@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSummarySidecarWrittenNextToPDF(t *testing.T) {
+	repo := newTestRepo(t, "First change", "Second change")
+	report := filepath.Join(t.TempDir(), "report.pdf")
+	args := []string{"--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--output", report}
+
+	if err := execute(t, args...); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+	if _, err := os.Stat(report + ".summary.json"); !os.IsNotExist(err) {
+		t.Fatalf("expected no sidecar without --summary-sidecar, got %v", err)
+	}
+
+	if err := execute(t, append(args, "--summary-sidecar")...); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+	if _, err := os.Stat(report); err != nil {
+		t.Errorf("expected the PDF report: %v", err)
+	}
+	content, err := os.ReadFile(report + ".summary.json")
+	if err != nil {
+		t.Fatalf("expected the summary sidecar next to the PDF: %v", err)
+	}
+	var summary struct {
+		Commits int `json:"commits"`
+	}
+	if err := json.Unmarshal(content, &summary); err != nil {
+		t.Fatalf("failed to parse summary: %v", err)
+	}
+	if summary.Commits != 2 {
+		t.Errorf("summary has %d commits, want 2", summary.Commits)
+	}
+}
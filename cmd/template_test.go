@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFileReplacesHeaderTemplate(t *testing.T) {
+	repo := newTestRepo(t, "First change")
+	dir := t.TempDir()
+	template := filepath.Join(dir, "header.tmpl")
+	content := "Kraków, {{.date_from}}\nProtokół z pliku\n\nTreść szablonu z pliku"
+	if err := os.WriteFile(template, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	output := filepath.Join(dir, "report.md")
+	args := []string{"--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--output", output}
+	if err := execute(t, append(args, "--template-file", template)...); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+	report, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	for _, want := range []string{"Kraków, 2024-01-01", "# Protokół z pliku", "Treść szablonu z pliku"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("expected %q from the template file in:\n%s", want, report)
+		}
+	}
+
+	if err := execute(t, append(args, "--template-file", filepath.Join(dir, "missing.tmpl"))...); err == nil ||
+		!strings.Contains(err.Error(), "header template file") {
+		t.Errorf("expected an error for a missing template file, got %v", err)
+	}
+
+	empty := filepath.Join(dir, "empty.tmpl")
+	if err := os.WriteFile(empty, []byte("  \n"), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	if err := execute(t, append(args, "--template-file", empty)...); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Errorf("expected an error for an empty template file, got %v", err)
+	}
+}
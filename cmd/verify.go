@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"git-report-generator/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// verifyChecksumCmd re-derives the commit set of a generated PDF report from
+// the repository and compares it against the checksum embedded in the report
+var verifyChecksumCmd = &cobra.Command{
+	Use:   "verify-checksum <report.pdf>",
+	Short: "Verify that a PDF report matches the commits in the repository",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runVerifyChecksum,
+}
+
+// verifiedSHAs holds the listed commit hashes of a report being verified,
+// standing in for its --sha-list file
+var verifiedSHAs []string
+
+// proofParams returns the resolved selection parameters of the report, so the
+// same commit set can be queried again when verifying its checksum. The full
+// hashes of a listed commit set are recorded as they are.
+func proofParams(fromDate, toDate time.Time, loc *time.Location, listed []string) url.Values {
+	values := url.Values{}
+	values.Set("from", fromDate.Format("2006-01-02"))
+	values.Set("to", toDate.Format("2006-01-02"))
+	values.Set("timezone", loc.String())
+	if configPath != "" {
+		// Filters and grouping set in the file are replayed by loading it again
+		if path, err := filepath.Abs(configPath); err == nil {
+			values.Set("config", path)
+		}
+	}
+	values.Set("branch", branch)
+	if len(branches) > 1 {
+		for _, extra := range branches[1:] {
+			values.Add("branch", extra)
+		}
+	}
+	values.Set("sort", sortOrder)
+	if scope == "repo" {
+		values.Set("scope", scope)
+	}
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	setIfNotEmpty("author", authorEmail)
+	if len(authorEmails) > 1 {
+		for _, extra := range authorEmails[1:] {
+			values.Add("author", extra)
+		}
+	}
+	setIfNotEmpty("author_name", authorName)
+	setIfNotEmpty("merge_base_with", mergeBase)
+	setIfNotEmpty("range", revRange)
+	setIfNotEmpty("group_by", groupBy)
+	if authorRegex {
+		values.Set("author_name_regex", "true")
+	}
+	if authorLocal {
+		values.Set("author_local_time", "true")
+	}
+	if followRename {
+		values.Set("follow_renames", "true")
+	}
+	if noMailmap {
+		values.Set("no_mailmap", "true")
+	}
+	if dedupe {
+		values.Set("dedupe_by_message", "true")
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	if minCommits > 0 {
+		values.Set("min_commits", strconv.Itoa(minCommits))
+	}
+	for _, path := range paths {
+		values.Add("path", path)
+	}
+	for _, hash := range listed {
+		values.Add("sha", hash)
+	}
+	return values
+}
+
+// applyProofParams sets the filters from parameters embedded in a report
+func applyProofParams(values url.Values) error {
+	dateFrom = values.Get("from")
+	dateTo = values.Get("to")
+	timezone = values.Get("timezone")
+	tzFromGit = false
+	branch = values.Get("branch")
+	branches = values["branch"]
+	sortOrder = values.Get("sort")
+	scope = values.Get("scope")
+	if scope == "" {
+		scope = "branch"
+	}
+	given := authorEmails
+	authorEmail = values.Get("author")
+	authorEmails = values["author"]
+	if digests := values["author_sha256"]; len(digests) > 0 {
+		// Reports with redacted emails only carry digests of the author
+		// filter, so the addresses have to be passed again
+		if !matchesDigests(given, digests) {
+			return fmt.Errorf("the report was filtered by redacted author emails, pass the same --author flags to verify it")
+		}
+		authorEmail = given[0]
+		authorEmails = given
+	}
+	authorName = values.Get("author_name")
+	mergeBase = values.Get("merge_base_with")
+	revRange = values.Get("range")
+	groupBy = values.Get("group_by")
+	authorRegex = values.Get("author_name_regex") == "true"
+	authorLocal = values.Get("author_local_time") == "true"
+	followRename = values.Get("follow_renames") == "true"
+	noMailmap = values.Get("no_mailmap") == "true"
+	dedupe = values.Get("dedupe_by_message") == "true"
+	paths = values["path"]
+	shaList = ""
+	verifiedSHAs = values["sha"]
+	if configPath == "" {
+		configPath = values.Get("config")
+	}
+
+	limit = 0
+	if value := values.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid limit in checksum metadata: %w", err)
+		}
+		limit = n
+	}
+
+	minCommits = 0
+	if value := values.Get("min_commits"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid min commits in checksum metadata: %w", err)
+		}
+		minCommits = n
+	}
+
+	return nil
+}
+
+// matchesDigests reports whether the emails are exactly the ones the digests
+// were computed from, in any order
+func matchesDigests(emails, digests []string) bool {
+	if len(emails) != len(digests) {
+		return false
+	}
+	remaining := make(map[string]int)
+	for _, digest := range digests {
+		remaining[digest]++
+	}
+	for _, email := range emails {
+		digest := generator.EmailDigest(email)
+		if remaining[digest] == 0 {
+			return false
+		}
+		remaining[digest]--
+	}
+	return true
+}
+
+func runVerifyChecksum(cmd *cobra.Command, args []string) error {
+	proof, err := generator.ReadProof(args[0])
+	if err != nil {
+		return err
+	}
+	if err := applyProofParams(proof); err != nil {
+		return err
+	}
+
+	reportData, err := loadReportData()
+	if err != nil {
+		return err
+	}
+
+	expected := proof.Get("digest")
+	actual := generator.CommitSetDigest(reportData.Commits)
+	if actual != expected {
+		fmt.Printf("❌ Checksum mismatch for %s\n", args[0])
+		fmt.Printf("   report:     %s\n", expected)
+		fmt.Printf("   repository: %s (%d commits)\n", actual, len(reportData.Commits))
+		return fmt.Errorf("report does not match the repository")
+	}
+
+	fmt.Printf("✅ Checksum matches: %s (%d commits)\n", actual, len(reportData.Commits))
+	return nil
+}
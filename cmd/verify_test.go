@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestVerifyChecksumMatchAndTampered(t *testing.T) {
+	repo := newTestRepo(t, "First change", "Second change")
+	report := filepath.Join(t.TempDir(), "report.pdf")
+	if err := execute(t, "--repo", repo, "--from", "2024-01-01", "--to", "2024-01-31",
+		"--author", testAuthor.Email, "--output", report); err != nil {
+		t.Fatalf("failed to generate report: %v", err)
+	}
+
+	if err := execute(t, "verify-checksum", report, "--repo", repo); err != nil {
+		t.Errorf("untouched report failed verification: %v", err)
+	}
+
+	content, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	digest := regexp.MustCompile(`digest=sha256%3A([0-9a-f]{64})`).FindSubmatch(content)
+	if digest == nil {
+		t.Fatal("report has no digest in its metadata")
+	}
+	forged := bytes.Repeat([]byte("0"), len(digest[1]))
+	tampered := filepath.Join(t.TempDir(), "tampered.pdf")
+	if err := os.WriteFile(tampered, bytes.Replace(content, digest[1], forged, 1), 0644); err != nil {
+		t.Fatalf("failed to write tampered report: %v", err)
+	}
+
+	if err := execute(t, "verify-checksum", tampered, "--repo", repo); err == nil {
+		t.Error("tampered report passed verification")
+	}
+}
@@ -0,0 +1,13 @@
+// Package fonts embeds the DejaVu fonts the PDF report is rendered with when
+// they are not installed next to the binary with "fonts install"
+package fonts
+
+import "embed"
+
+// Files lists the embedded font files
+var Files = []string{"DejaVuSans.ttf", "DejaVuSans-Bold.ttf", "DejaVuSans-Oblique.ttf"}
+
+// FS holds the embedded font files
+//
+//go:embed DejaVuSans.ttf DejaVuSans-Bold.ttf DejaVuSans-Oblique.ttf
+var FS embed.FS
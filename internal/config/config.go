@@ -1,72 +1,471 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds the configuration for the report generator
 type Config struct {
+	// Language of month and weekday names ("pl" or "en")
+	Lang string `json:"lang" yaml:"lang"`
+
+	// Group the digits of rendered numbers in the style of Lang, e.g.
+	// "1 234" in pl and "1,234" in en, with its decimal separator
+	LocalizedNumbers bool `json:"localized_numbers" yaml:"localized_numbers"`
+
 	// Header template configuration
-	Header HeaderConfig `json:"header"`
+	Header HeaderConfig `json:"header" yaml:"header"`
 
 	// PDF styling configuration
-	PDF PDFConfig `json:"pdf"`
+	PDF PDFConfig `json:"pdf" yaml:"pdf"`
+
+	// Report footer configuration
+	Footer FooterConfig `json:"footer" yaml:"footer"`
+
+	// Commit statistics configuration
+	Stats StatsConfig `json:"stats" yaml:"stats"`
+
+	// Commit message parsing configuration
+	CommitParsing CommitParsingConfig `json:"commit_parsing" yaml:"commit_parsing"`
+
+	// HTML output configuration
+	HTML HTMLConfig `json:"html" yaml:"html"`
+
+	// Ordering of grouped reports
+	Sort SortConfig `json:"sort" yaml:"sort"`
+
+	// Privacy options for reports shared outside the team
+	Privacy PrivacyConfig `json:"privacy" yaml:"privacy"`
+
+	// Summary block configuration
+	Summary SummaryConfig `json:"summary" yaml:"summary"`
+
+	// Commit policies checked by the audit command
+	Audit AuditConfig `json:"audit" yaml:"audit"`
+
+	// Effort estimation derived from commit times
+	Estimation EstimationConfig `json:"estimation" yaml:"estimation"`
+
+	// Named report profiles selectable with --profile
+	Profiles map[string]ProfileConfig `json:"profiles" yaml:"profiles"`
+
+	// Per-author settings keyed by email
+	Authors map[string]AuthorConfig `json:"authors" yaml:"authors"`
+}
+
+// AuthorConfig holds the settings of a single author
+type AuthorConfig struct {
+	// Color of the author's labels with pdf.author_colors, RGB values 0-255.
+	// Unset colors are derived from the email.
+	Color *[3]int `json:"color" yaml:"color"`
+}
+
+// ProfileConfig holds default dates and filters for a recurring report.
+// Dates may be absolute (YYYY-MM-DD) or relative (e.g. "1m" for one month ago).
+type ProfileConfig struct {
+	From   string `json:"from" yaml:"from"`
+	To     string `json:"to" yaml:"to"`
+	Author string `json:"author" yaml:"author"`
+	Branch string `json:"branch" yaml:"branch"`
 }
 
 // HeaderConfig contains the configurable header template
 type HeaderConfig struct {
 	// Template for the header with placeholders
-	Template string `json:"template"`
+	Template string `json:"template" yaml:"template"`
+
+	// Path to a file with the header template, overriding Template
+	TemplateFile string `json:"template_file" yaml:"template_file"`
 
 	// Default executor information
-	ExecutorName  string `json:"executor_name"`
-	ExecutorEmail string `json:"executor_email"`
+	ExecutorName  string `json:"executor_name" yaml:"executor_name"`
+	ExecutorEmail string `json:"executor_email" yaml:"executor_email"`
 
 	// Default recipient information
-	RecipientName string `json:"recipient_name"`
+	RecipientName string `json:"recipient_name" yaml:"recipient_name"`
+
+	// Person who prepared the report, if not the executor
+	PreparedBy string `json:"prepared_by" yaml:"prepared_by"`
 
 	// Location for the report
-	Location string `json:"location"`
+	Location string `json:"location" yaml:"location"`
+
+	// Go time layout of the report dates ({{date_from}}, {{date_to}}, the
+	// period); month and weekday names follow the report language
+	DateFormat string `json:"date_format" yaml:"date_format"`
+
+	// Displayed repository name (defaults to the repository directory name)
+	RepositoryName string `json:"repository_name" yaml:"repository_name"`
+
+	// Image (PNG, JPEG or GIF) drawn at the top of the first page
+	Logo string `json:"logo" yaml:"logo"`
+
+	// Placement of the logo ("L", "C" or "R")
+	LogoAlign string `json:"logo_align" yaml:"logo_align"`
+
+	// Maximum logo size in mm; with only one set, the other follows the
+	// image aspect ratio (0 for unset, 15 mm high when both are unset)
+	LogoWidth  float64 `json:"logo_width" yaml:"logo_width"`
+	LogoHeight float64 `json:"logo_height" yaml:"logo_height"`
+}
+
+// FooterConfig contains the configurable report footer
+type FooterConfig struct {
+	// Legal notice rendered in small text at the end of the report; supports
+	// the same placeholders as the header template
+	Disclaimer string `json:"disclaimer" yaml:"disclaimer"`
+
+	// Repeat the disclaimer at the bottom of every page instead
+	DisclaimerEveryPage bool `json:"disclaimer_every_page" yaml:"disclaimer_every_page"`
 }
 
 // PDFConfig contains PDF styling options
 type PDFConfig struct {
 	// Page margins
-	MarginTop    float64 `json:"margin_top"`
-	MarginBottom float64 `json:"margin_bottom"`
-	MarginLeft   float64 `json:"margin_left"`
-	MarginRight  float64 `json:"margin_right"`
+	MarginTop    float64 `json:"margin_top" yaml:"margin_top"`
+	MarginBottom float64 `json:"margin_bottom" yaml:"margin_bottom"`
+	MarginLeft   float64 `json:"margin_left" yaml:"margin_left"`
+	MarginRight  float64 `json:"margin_right" yaml:"margin_right"`
 
 	// Font settings
-	FontFamily string  `json:"font_family"`
-	FontSize   float64 `json:"font_size"`
+	FontFamily string  `json:"font_family" yaml:"font_family"`
+	FontSize   float64 `json:"font_size" yaml:"font_size"`
+
+	// TrueType font files used, in order, for commit table glyphs missing in
+	// the primary font (e.g. CJK)
+	FallbackFonts []string `json:"fallback_fonts" yaml:"fallback_fonts"`
+
+	// Per-section font sizes
+	FontSizes FontSizesConfig `json:"font_sizes" yaml:"font_sizes"`
+
+	// Commit table column widths
+	Table TableConfig `json:"table" yaml:"table"`
 
 	// Colors (RGB values 0-255)
-	HeaderColor  [3]int `json:"header_color"`
-	ContentColor [3]int `json:"content_color"`
+	HeaderColor  [3]int `json:"header_color" yaml:"header_color"`
+	ContentColor [3]int `json:"content_color" yaml:"content_color"`
+
+	// Page background color (RGB values 0-255), none when unset
+	BackgroundColor *[3]int `json:"background_color" yaml:"background_color"`
+
+	// Lowest WCAG contrast ratio of the text colors against the background
+	// accepted without a warning (0 disables the check)
+	MinContrast float64 `json:"min_contrast" yaml:"min_contrast"`
+
+	// Draw a thin frame around the content of every page
+	PageBorder bool `json:"page_border" yaml:"page_border"`
+
+	// Start the report with a title page
+	CoverPage bool `json:"cover_page" yaml:"cover_page"`
+
+	// PNG, JPEG or GIF image covering the whole title page, none when empty
+	CoverImage string `json:"cover_image" yaml:"cover_image"`
+
+	// Render all dates in UTC instead of their original zone
+	DisplayUTC bool `json:"display_utc" yaml:"display_utc"`
+
+	// Use the compact one-line-per-commit layout
+	Compact bool `json:"compact" yaml:"compact"`
+
+	// How SHAs that do not fit the SHA column are handled ("auto", "wrap" or "truncate")
+	SHAColumnPolicy string `json:"sha_column_policy" yaml:"sha_column_policy"`
+
+	// Draw author section headings of grouped reports in a per-author color
+	AuthorColors bool `json:"author_colors" yaml:"author_colors"`
+
+	// Vertical position of short cells in taller table rows ("top" or "middle")
+	CellVAlign string `json:"cell_valign" yaml:"cell_valign"`
+
+	// Show the summary block below the commit table
+	ShowSummary bool `json:"show_summary" yaml:"show_summary"`
+
+	// Show active-day and longest-streak metrics in the summary
+	ShowStreaks bool `json:"show_streaks" yaml:"show_streaks"`
+
+	// Add a page with a weeks-by-weekdays heatmap of commit counts
+	ShowHeatmap bool `json:"show_heatmap" yaml:"show_heatmap"`
+
+	// Append a page ranking the authors by commits and net lines
+	ShowLeaderboard bool `json:"show_leaderboard" yaml:"show_leaderboard"`
+
+	// Title alignment ("L", "C" or "R")
+	TitleAlign string `json:"title_align" yaml:"title_align"`
+
+	// Render fenced code blocks of commit bodies in a monospace box
+	RenderCodeBlocks bool `json:"render_code_blocks" yaml:"render_code_blocks"`
+
+	// Scale the commit table columns and font down when they do not fit the page
+	AutoFitTable bool `json:"auto_fit_table" yaml:"auto_fit_table"`
+
+	// Page cap for font_size_auto (0 for none)
+	MaxPages int `json:"max_pages" yaml:"max_pages"`
+
+	// Warn and ask for confirmation before generating a report with more
+	// commits than this (0 to never warn)
+	WarnCommits int `json:"warn_commits" yaml:"warn_commits"`
+
+	// Shrink the commit table font step by step until the report fits
+	// max_pages pages
+	FontSizeAuto bool `json:"font_size_auto" yaml:"font_size_auto"`
+
+	// Maximum number of rendered description lines per commit (0 for no limit)
+	MaxDescriptionLines int `json:"max_description_lines" yaml:"max_description_lines"`
+
+	// Add rows for days of the report range without commits
+	IncludeEmptyDays bool `json:"include_empty_days" yaml:"include_empty_days"`
+
+	// Section the report by this key ("" for a single table, "author", "day",
+	// "release" or "branch")
+	GroupBy string `json:"group_by" yaml:"group_by"`
+
+	// Colors for section subtotals by commit count; the highest threshold
+	// reached applies
+	ActivityThresholds []ActivityThreshold `json:"activity_thresholds" yaml:"activity_thresholds"`
+}
+
+// ActivityThreshold colors section subtotals with at least MinCommits commits
+type ActivityThreshold struct {
+	MinCommits int    `json:"min_commits" yaml:"min_commits"`
+	Color      [3]int `json:"color" yaml:"color"` // RGB values 0-255
+}
+
+// Report grouping keys
+const (
+	GroupByAuthor = "author"
+	GroupByDay    = "day"
+
+	// GroupByRelease sections commits between consecutive tags
+	GroupByRelease = "release"
+
+	// GroupByBranch sections commits by the --branch they were found on
+	GroupByBranch = "branch"
+)
+
+// Sort directions
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// Commit table column widths, in millimeters on the A4 portrait page
+const (
+	PageWidth           = 210.0
+	DefaultDateColWidth = 30.0
+	DefaultSHAColWidth  = 25.0
+	TicketColWidth      = 25.0
+	NetColWidth         = 18.0
+	SplitColWidth       = 26.0
+	EffortColWidth      = 16.0
+)
+
+// SHA column policies
+const (
+	SHAPolicyAuto     = "auto"
+	SHAPolicyWrap     = "wrap"
+	SHAPolicyTruncate = "truncate"
+)
+
+// Vertical cell alignments
+const (
+	CellVAlignTop    = "top"
+	CellVAlignMiddle = "middle"
+)
+
+// StatsConfig contains options for per-commit change statistics
+type StatsConfig struct {
+	// Maximum number of changed lines for a commit's diff to be embedded
+	MaxDiffLines int `json:"max_diff_lines" yaml:"max_diff_lines"`
+
+	// Compute inserted/deleted line counts per commit
+	WithStats bool `json:"with_stats" yaml:"with_stats"`
+
+	// Show inserted and deleted lines as "+X/-Y" instead of the net change
+	SplitLines bool `json:"split_lines" yaml:"split_lines"`
+
+	// Compute bytes added/removed per commit from blob sizes
+	ByteStats bool `json:"byte_stats" yaml:"byte_stats"`
+
+	// Show inserted/deleted lines per file extension in the summary
+	FileTypeStats bool `json:"filetype_stats" yaml:"filetype_stats"`
+
+	// Compute statistics against this fixed ref instead of each commit's parent
+	StatAgainst string `json:"stat_against" yaml:"stat_against"`
+}
+
+// PrivacyConfig contains options for redacting personal data in reports
+type PrivacyConfig struct {
+	// Mask the local part of emails wherever they are rendered
+	RedactEmails bool `json:"redact_emails" yaml:"redact_emails"`
+}
+
+// EstimationConfig controls the estimated hours of work shown per commit and
+// in the summary
+type EstimationConfig struct {
+	// Show effort estimates
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Longest pause between two commits of an author still counted as work
+	SessionGapMinutes int `json:"session_gap_minutes" yaml:"session_gap_minutes"`
+
+	// Time credited to the first commit of a work session
+	FirstCommitMinutes int `json:"first_commit_minutes" yaml:"first_commit_minutes"`
+
+	// Round estimates up to "quarter_hour" or "hour", "" to keep them exact
+	Rounding string `json:"rounding" yaml:"rounding"`
+}
+
+// Effort estimate rounding modes
+const (
+	RoundingQuarterHour = "quarter_hour"
+	RoundingHour        = "hour"
+)
+
+// AuditConfig contains the policies every commit has to satisfy in an audit.
+// Zero values disable the respective policy.
+type AuditConfig struct {
+	// Require a PGP signature
+	RequireSigned bool `json:"require_signed" yaml:"require_signed"`
+
+	// Require a Conventional Commits subject such as "fix(parser): ..."
+	RequireConventional bool `json:"require_conventional" yaml:"require_conventional"`
+
+	// Maximum subject length in characters (0 for no limit)
+	MaxSubjectLength int `json:"max_subject_length" yaml:"max_subject_length"`
+
+	// Require a ticket reference, either captured by
+	// commit_parsing.strip_subject_prefix or matching TicketPattern
+	RequireTicket bool `json:"require_ticket" yaml:"require_ticket"`
+
+	// Regular expression finding ticket references in the commit message
+	TicketPattern string `json:"ticket_pattern" yaml:"ticket_pattern"`
+}
+
+// SummaryConfig contains options for the summary block below the commit table
+type SummaryConfig struct {
+	// How the author is shown ("email", "name" or "name_email")
+	AuthorDisplay string `json:"author_display" yaml:"author_display"`
+}
+
+// Summary author display modes
+const (
+	AuthorDisplayEmail     = "email"
+	AuthorDisplayName      = "name"
+	AuthorDisplayNameEmail = "name_email"
+)
+
+// SortConfig controls the order of sections and of the commits within them
+// when the report is grouped. Empty values keep the default order.
+type SortConfig struct {
+	// Order of the sections ("asc" or "desc"): by commit count for authors,
+	// chronologically for days
+	Groups string `json:"groups" yaml:"groups"`
+
+	// Chronological order of commits within a section ("asc" or "desc")
+	WithinGroup string `json:"within_group" yaml:"within_group"`
+}
+
+// HTMLConfig contains options for the HTML output format
+type HTMLConfig struct {
+	// Path to an html/template file replacing the built-in template
+	TemplateFile string `json:"template_file" yaml:"template_file"`
+}
+
+// CommitParsingConfig contains options for interpreting commit messages
+type CommitParsingConfig struct {
+	// Regular expression matching a subject prefix (e.g. `^\[([A-Z]+-\d+)\]\s*`)
+	// that is removed from the displayed subject. The first capture group, or
+	// the whole match, is kept as the commit's ticket.
+	StripSubjectPrefix string `json:"strip_subject_prefix" yaml:"strip_subject_prefix"`
+
+	// Show the captured tickets in a separate table column
+	ShowTicketColumn bool `json:"show_ticket_column" yaml:"show_ticket_column"`
+
+	// Keep Signed-off-by trailers in commit descriptions instead of
+	// stripping them
+	KeepSignOffs bool `json:"keep_sign_offs" yaml:"keep_sign_offs"`
+
+	// List the stripped sign-offs in a note below the description
+	ShowSignOffs bool `json:"show_sign_offs" yaml:"show_sign_offs"`
+
+	// Subject displayed for commits with an empty message
+	EmptyMessage string `json:"empty_message" yaml:"empty_message"`
+}
+
+// FontSizesConfig contains font sizes for individual report sections
+type FontSizesConfig struct {
+	Title   float64 `json:"title" yaml:"title"`
+	Body    float64 `json:"body" yaml:"body"`
+	Table   float64 `json:"table" yaml:"table"`
+	Summary float64 `json:"summary" yaml:"summary"`
+	Footer  float64 `json:"footer" yaml:"footer"`
+}
+
+// TableConfig contains the commit table column widths in millimeters. Zero
+// keeps the default width; the description column takes the remaining space.
+type TableConfig struct {
+	DateColWidth float64 `json:"date_col_width" yaml:"date_col_width"`
+	SHAColWidth  float64 `json:"sha_col_width" yaml:"sha_col_width"`
+}
+
+// fixedColumnsWidth returns the total width of the enabled commit table
+// columns besides the description
+func (c *Config) fixedColumnsWidth() float64 {
+	width := c.PDF.Table.DateColWidth
+	if width == 0 {
+		width = DefaultDateColWidth
+	}
+	if c.PDF.Table.SHAColWidth > 0 {
+		width += c.PDF.Table.SHAColWidth
+	} else {
+		width += DefaultSHAColWidth
+	}
+	if c.CommitParsing.ShowTicketColumn {
+		width += TicketColWidth
+	}
+	if c.Stats.WithStats {
+		if c.Stats.SplitLines {
+			width += SplitColWidth
+		} else {
+			width += NetColWidth
+		}
+	}
+	if c.Estimation.Enabled {
+		width += EffortColWidth
+	}
+	return width
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
+		Lang: "pl",
 		Header: HeaderConfig{
 			Template: `Some City, {{.date_from}} - {{.date_to}}
 Protokół odbioru prac programistycznych
 
 Wykonawca: {{.executor_name}} ({{.executor_email}})
 Odbiorca: {{.recipient_name}}
+Sporządził: {{.prepared_by}}
 
 Repozytorium: {{.repository_name}}
 - Branch {{.branch_name}}
+- Commit {{.head_hash}}
 
 Lista commitów:`,
 			ExecutorName:  "Some Programmer",
 			ExecutorEmail: "jan.kowalski@comany.com",
 			RecipientName: "CIA",
 			Location:      "Warsaw",
+			DateFormat:    "2006-01-02",
+			LogoAlign:     "R",
 		},
 		PDF: PDFConfig{
 			MarginTop:    20,
@@ -75,45 +474,130 @@ Lista commitów:`,
 			MarginRight:  20,
 			FontFamily:   "Arial",
 			FontSize:     10,
-			HeaderColor:  [3]int{0, 0, 0},
-			ContentColor: [3]int{50, 50, 50},
+			FontSizes: FontSizesConfig{
+				Title:   16,
+				Body:    11,
+				Table:   10,
+				Summary: 10,
+				Footer:  8,
+			},
+			HeaderColor:     [3]int{0, 0, 0},
+			ContentColor:    [3]int{50, 50, 50},
+			MinContrast:     4.5,
+			SHAColumnPolicy: SHAPolicyAuto,
+			CellVAlign:      CellVAlignTop,
+			ShowSummary:     true,
+			TitleAlign:      "C",
+			WarnCommits:     1000,
+		},
+		Stats: StatsConfig{
+			MaxDiffLines: 50,
+		},
+		Summary: SummaryConfig{
+			AuthorDisplay: AuthorDisplayEmail,
+		},
+		Estimation: EstimationConfig{
+			SessionGapMinutes:  120,
+			FirstCommitMinutes: 30,
+		},
+		CommitParsing: CommitParsingConfig{
+			EmptyMessage: "(brak opisu)",
+		},
+		Audit: AuditConfig{
+			TicketPattern: `[A-Z][A-Z0-9]+-\d+`,
 		},
 	}
 }
 
-// Load loads configuration from file or returns default if file doesn't exist
+// Load loads configuration from the user-global file and configPath layered
+// over the defaults, or returns the defaults if neither is given
 func Load(configPath string) (*Config, error) {
-	// If no config path provided, return default config
-	if configPath == "" {
-		return DefaultConfig(), nil
-	}
+	return load(configPath, false)
+}
 
-	// Check if config file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("configuration file not found: %s", configPath)
-	}
+// LoadStrict is like Load but rejects keys that do not correspond to any
+// configuration field, catching typos that would otherwise be ignored
+func LoadStrict(configPath string) (*Config, error) {
+	return load(configPath, true)
+}
 
-	// Read config file
-	data, err := os.ReadFile(configPath)
+// GlobalPath returns the location of the user-global configuration file,
+// $XDG_CONFIG_HOME/gitreport/config.json or the OS equivalent. It returns an
+// empty string when no user configuration directory can be determined.
+func GlobalPath() string {
+	dir, err := os.UserConfigDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+		return ""
+	}
+	return filepath.Join(dir, "gitreport", "config.json")
+}
+
+func load(configPath string, strict bool) (*Config, error) {
+	config := DefaultConfig()
+
+	// Layer the user-global configuration over the defaults when it exists
+	if globalPath := GlobalPath(); globalPath != "" {
+		if _, err := os.Stat(globalPath); err == nil {
+			if err := decodeFile(globalPath, config, strict); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// Parse JSON
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
+	// The project configuration overrides both the defaults and the global file
+	if configPath != "" {
+		// Check if config file exists
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("configuration file not found: %s", configPath)
+		}
+
+		if err := decodeFile(configPath, config, strict); err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	if warnings := config.Warnings(); strict && len(warnings) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %s", strings.Join(warnings, "; "))
+	}
+
+	return config, nil
+}
+
+// decodeFile parses the configuration file at path on top of config, so
+// omitted fields keep the values already set
+func decodeFile(path string, config *Config, strict bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	decodeData := decode
+	if isYAML(path) {
+		decodeData = decodeYAML
+	}
+	if err := decodeData(data, config, strict); err != nil {
+		return fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+	return nil
+}
 
-	return &config, nil
+// isYAML reports whether the configuration file at path is YAML, judged by
+// its extension; anything else, including no extension, is JSON
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
 }
 
-// Save saves the configuration to a file
+// Save saves the configuration to a file, as YAML for .yaml and .yml paths
+// and as JSON otherwise
 func (c *Config) Save(configPath string) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(configPath)
@@ -121,10 +605,25 @@ func (c *Config) Save(configPath string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Marshal to JSON with indentation
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal configuration: %w", err)
+	var data []byte
+	if isYAML(configPath) {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(c); err != nil {
+			return fmt.Errorf("failed to marshal configuration: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("failed to marshal configuration: %w", err)
+		}
+		data = buf.Bytes()
+	} else {
+		// Marshal to JSON with indentation
+		var err error
+		data, err = json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal configuration: %w", err)
+		}
 	}
 
 	// Write to file
@@ -141,14 +640,169 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("header template cannot be empty")
 	}
 
+	switch c.Lang {
+	case "pl", "en":
+	default:
+		return fmt.Errorf("invalid language %q (use pl or en)", c.Lang)
+	}
+
+	if c.Header.DateFormat == "" {
+		return fmt.Errorf("header date format cannot be empty")
+	}
+
 	if c.PDF.FontSize <= 0 {
 		return fmt.Errorf("font size must be positive")
 	}
 
+	sizes := c.PDF.FontSizes
+	if sizes.Title <= 0 || sizes.Body <= 0 || sizes.Table <= 0 ||
+		sizes.Summary <= 0 || sizes.Footer <= 0 {
+		return fmt.Errorf("section font sizes must be positive")
+	}
+
 	if c.PDF.MarginTop < 0 || c.PDF.MarginBottom < 0 ||
 		c.PDF.MarginLeft < 0 || c.PDF.MarginRight < 0 {
 		return fmt.Errorf("margins cannot be negative")
 	}
 
+	table := c.PDF.Table
+	if table.DateColWidth < 0 || table.SHAColWidth < 0 {
+		return fmt.Errorf("table column widths cannot be negative")
+	}
+	// Auto-fit scales the table down instead
+	if fixed, available := c.fixedColumnsWidth(), PageWidth-c.PDF.MarginLeft-c.PDF.MarginRight; !c.PDF.AutoFitTable && fixed >= available {
+		return fmt.Errorf("table columns (%gmm) leave no room for the description within the %gmm between the margins", fixed, available)
+	}
+
+	switch c.PDF.SHAColumnPolicy {
+	case SHAPolicyAuto, SHAPolicyWrap, SHAPolicyTruncate:
+	default:
+		return fmt.Errorf("invalid SHA column policy %q (use auto, wrap or truncate)", c.PDF.SHAColumnPolicy)
+	}
+
+	for email, author := range c.Authors {
+		if author.Color == nil {
+			continue
+		}
+		for _, value := range author.Color {
+			if value < 0 || value > 255 {
+				return fmt.Errorf("color values of author %s must be between 0 and 255", email)
+			}
+		}
+	}
+
+	switch c.PDF.CellVAlign {
+	case CellVAlignTop, CellVAlignMiddle:
+	default:
+		return fmt.Errorf("invalid cell vertical alignment %q (use top or middle)", c.PDF.CellVAlign)
+	}
+
+	switch c.PDF.GroupBy {
+	case "", GroupByAuthor, GroupByDay, GroupByRelease, GroupByBranch:
+	default:
+		return fmt.Errorf("invalid group by %q (use author, day, release or branch)", c.PDF.GroupBy)
+	}
+
+	if c.CommitParsing.StripSubjectPrefix != "" {
+		if _, err := regexp.Compile(c.CommitParsing.StripSubjectPrefix); err != nil {
+			return fmt.Errorf("invalid strip_subject_prefix pattern: %w", err)
+		}
+	}
+
+	if c.PDF.MinContrast < 0 || c.PDF.MinContrast > 21 {
+		return fmt.Errorf("min contrast must be between 0 and 21")
+	}
+
+	if bg := c.PDF.BackgroundColor; bg != nil {
+		for _, value := range bg {
+			if value < 0 || value > 255 {
+				return fmt.Errorf("background color values must be between 0 and 255")
+			}
+		}
+	}
+
+	for _, threshold := range c.PDF.ActivityThresholds {
+		if threshold.MinCommits < 0 {
+			return fmt.Errorf("activity threshold min_commits cannot be negative")
+		}
+		for _, value := range threshold.Color {
+			if value < 0 || value > 255 {
+				return fmt.Errorf("activity threshold colors must be between 0 and 255")
+			}
+		}
+	}
+
+	for name, direction := range map[string]string{"groups": c.Sort.Groups, "within group": c.Sort.WithinGroup} {
+		switch direction {
+		case "", SortAscending, SortDescending:
+		default:
+			return fmt.Errorf("invalid %s sort order %q (use asc or desc)", name, direction)
+		}
+	}
+
+	switch c.PDF.TitleAlign {
+	case "L", "C", "R":
+	default:
+		return fmt.Errorf("invalid title alignment %q (use L, C or R)", c.PDF.TitleAlign)
+	}
+	switch c.Header.LogoAlign {
+	case "L", "C", "R":
+	default:
+		return fmt.Errorf("invalid logo alignment %q (use L, C or R)", c.Header.LogoAlign)
+	}
+	if c.Header.LogoWidth < 0 || c.Header.LogoHeight < 0 {
+		return fmt.Errorf("logo size cannot be negative")
+	}
+
+	switch c.Summary.AuthorDisplay {
+	case AuthorDisplayEmail, AuthorDisplayName, AuthorDisplayNameEmail:
+	default:
+		return fmt.Errorf("invalid summary author display %q (use email, name or name_email)", c.Summary.AuthorDisplay)
+	}
+
+	if c.Estimation.SessionGapMinutes < 0 || c.Estimation.FirstCommitMinutes < 0 {
+		return fmt.Errorf("estimation minutes cannot be negative")
+	}
+	switch c.Estimation.Rounding {
+	case "", RoundingQuarterHour, RoundingHour:
+	default:
+		return fmt.Errorf("invalid estimation rounding %q (use quarter_hour or hour)", c.Estimation.Rounding)
+	}
+
+	if c.Audit.MaxSubjectLength < 0 {
+		return fmt.Errorf("audit max subject length cannot be negative")
+	}
+	if _, err := regexp.Compile(c.Audit.TicketPattern); err != nil {
+		return fmt.Errorf("invalid audit ticket_pattern: %w", err)
+	}
+
+	if c.PDF.MaxPages < 0 {
+		return fmt.Errorf("max pages cannot be negative")
+	}
+	if c.PDF.WarnCommits < 0 {
+		return fmt.Errorf("warn commits cannot be negative")
+	}
+	if c.PDF.FontSizeAuto && c.PDF.MaxPages == 0 {
+		return fmt.Errorf("font_size_auto requires max_pages to be set")
+	}
+
+	if c.PDF.CoverImage != "" && !c.PDF.CoverPage {
+		return fmt.Errorf("cover_image requires cover_page to be enabled")
+	}
+
+	for _, path := range c.PDF.FallbackFonts {
+		if path == "" {
+			return fmt.Errorf("fallback font paths cannot be empty")
+		}
+	}
+
+	if c.PDF.MaxDescriptionLines < 0 {
+		return fmt.Errorf("max description lines cannot be negative")
+	}
+
+	if c.Stats.MaxDiffLines < 0 {
+		return fmt.Errorf("max diff lines cannot be negative")
+	}
+
 	return nil
 }
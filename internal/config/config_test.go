@@ -0,0 +1,204 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRejectsNonPositiveFontSizes(t *testing.T) {
+	for name, set := range map[string]func(*FontSizesConfig){
+		"title":   func(s *FontSizesConfig) { s.Title = 0 },
+		"body":    func(s *FontSizesConfig) { s.Body = -1 },
+		"table":   func(s *FontSizesConfig) { s.Table = 0 },
+		"summary": func(s *FontSizesConfig) { s.Summary = 0 },
+		"footer":  func(s *FontSizesConfig) { s.Footer = 0 },
+	} {
+		cfg := DefaultConfig()
+		set(&cfg.PDF.FontSizes)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("default configuration is invalid: %v", err)
+	}
+}
+
+func TestValidateTitleAlignment(t *testing.T) {
+	for _, align := range []string{"L", "C", "R"} {
+		cfg := DefaultConfig()
+		cfg.PDF.TitleAlign = align
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("%s: unexpected validation error: %v", align, err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.PDF.TitleAlign = "center"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected a validation error for an unknown alignment")
+	}
+}
+
+func TestValidateTableColumnWidths(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   func(*Config)
+		valid bool
+	}{
+		{"defaults", func(c *Config) {}, true},
+		{"wider date column", func(c *Config) { c.PDF.Table.DateColWidth = 50 }, true},
+		{"negative width", func(c *Config) { c.PDF.Table.SHAColWidth = -1 }, false},
+		{"no room for the description", func(c *Config) {
+			c.PDF.Table = TableConfig{DateColWidth: 100, SHAColWidth: 70}
+		}, false},
+		{"narrower margins make room", func(c *Config) {
+			c.PDF.Table = TableConfig{DateColWidth: 100, SHAColWidth: 70}
+			c.PDF.MarginLeft, c.PDF.MarginRight = 10, 10
+		}, true},
+		{"enabled columns count", func(c *Config) {
+			c.PDF.Table = TableConfig{DateColWidth: 60, SHAColWidth: 55}
+			c.CommitParsing.ShowTicketColumn = true
+			c.Stats.WithStats = true
+			c.Estimation.Enabled = true
+		}, false},
+		{"auto-fit scales instead", func(c *Config) {
+			c.PDF.Table = TableConfig{DateColWidth: 100, SHAColWidth: 70}
+			c.PDF.AutoFitTable = true
+		}, true},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		tt.set(cfg)
+		if err := cfg.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: valid = %v, got error %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+// writeFile writes content to the named file under dir and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestGlobalConfigOverriddenByLocal(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	writeFile(t, configHome, "gitreport/config.json",
+		`{"header": {"executor_name": "Jan Kowalski", "recipient_name": "Acme"}}`)
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("failed to load global config: %v", err)
+	}
+	if cfg.Header.ExecutorName != "Jan Kowalski" || cfg.Header.RecipientName != "Acme" {
+		t.Errorf("global values = %q, %q, want Jan Kowalski, Acme", cfg.Header.ExecutorName, cfg.Header.RecipientName)
+	}
+
+	local := writeFile(t, t.TempDir(), "config.json", `{"header": {"recipient_name": "Globex"}}`)
+	cfg, err = Load(local)
+	if err != nil {
+		t.Fatalf("failed to load local config: %v", err)
+	}
+	if cfg.Header.ExecutorName != "Jan Kowalski" {
+		t.Errorf("executor = %q, want the global Jan Kowalski", cfg.Header.ExecutorName)
+	}
+	if cfg.Header.RecipientName != "Globex" {
+		t.Errorf("recipient = %q, want the local Globex", cfg.Header.RecipientName)
+	}
+	if cfg.PDF.FontSizes != DefaultConfig().PDF.FontSizes {
+		t.Errorf("font sizes = %+v, want the defaults", cfg.PDF.FontSizes)
+	}
+}
+
+func TestLoadYAMLByExtension(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	content := "# Header shown above the commit table\nheader:\n  executor_name: Jan Kowalski\npdf:\n  margin_left: 15\n"
+
+	for _, name := range []string{"config.yaml", "config.yml", "config.YAML"} {
+		cfg, err := Load(writeFile(t, dir, name, content))
+		if err != nil {
+			t.Fatalf("%s: failed to load: %v", name, err)
+		}
+		if cfg.Header.ExecutorName != "Jan Kowalski" || cfg.PDF.MarginLeft != 15 {
+			t.Errorf("%s: executor = %q, left margin = %v, want Jan Kowalski, 15", name, cfg.Header.ExecutorName, cfg.PDF.MarginLeft)
+		}
+		if cfg.PDF.MarginRight != DefaultConfig().PDF.MarginRight {
+			t.Errorf("%s: right margin = %v, want the default", name, cfg.PDF.MarginRight)
+		}
+	}
+
+	// Without an extension the file is JSON
+	if _, err := Load(writeFile(t, dir, "config", content)); err == nil {
+		t.Error("expected YAML content without an extension to fail as JSON")
+	}
+	if _, err := Load(writeFile(t, dir, "config", `{"lang": "en"}`)); err != nil {
+		t.Errorf("failed to load JSON without an extension: %v", err)
+	}
+
+	if _, err := LoadStrict(writeFile(t, dir, "strict.yaml", "header:\n  executor_nam: Jan\n")); err == nil {
+		t.Error("strict load accepted an unknown YAML key")
+	}
+}
+
+func TestSaveRoundTripsByExtension(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg := DefaultConfig()
+	cfg.Lang = "en"
+	cfg.Header.ExecutorName = "Jan Kowalski"
+	cfg.PDF.Table.DateColWidth = 40
+
+	for _, tt := range []struct {
+		name   string
+		prefix string
+	}{
+		{"config.yaml", "lang: en\n"},
+		{"config.json", "{\n"},
+	} {
+		path := filepath.Join(t.TempDir(), "nested", tt.name)
+		if err := cfg.Save(path); err != nil {
+			t.Fatalf("%s: failed to save: %v", tt.name, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%s: failed to read: %v", tt.name, err)
+		}
+		if !strings.HasPrefix(string(data), tt.prefix) {
+			t.Errorf("%s: saved as %q..., want it to start with %q", tt.name, data[:10], tt.prefix)
+		}
+
+		loaded, err := LoadStrict(path)
+		if err != nil {
+			t.Fatalf("%s: failed to load the saved file: %v", tt.name, err)
+		}
+		if loaded.Lang != "en" || loaded.Header.ExecutorName != "Jan Kowalski" || loaded.PDF.Table.DateColWidth != 40 {
+			t.Errorf("%s: loaded lang %q, executor %q, date width %v", tt.name,
+				loaded.Lang, loaded.Header.ExecutorName, loaded.PDF.Table.DateColWidth)
+		}
+
+		// Saving the loaded configuration again writes the same file
+		resaved := filepath.Join(t.TempDir(), tt.name)
+		if err := loaded.Save(resaved); err != nil {
+			t.Fatalf("%s: failed to save again: %v", tt.name, err)
+		}
+		again, err := os.ReadFile(resaved)
+		if err != nil {
+			t.Fatalf("%s: failed to read: %v", tt.name, err)
+		}
+		if string(again) != string(data) {
+			t.Errorf("%s: saving the loaded configuration changed the file", tt.name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// ContrastRatio returns the WCAG contrast ratio of two RGB colors, from 1
+// for identical colors to 21 for black on white
+func ContrastRatio(a, b [3]int) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance returns the WCAG relative luminance of an sRGB color
+func relativeLuminance(color [3]int) float64 {
+	var channels [3]float64
+	for i, value := range color {
+		c := float64(value) / 255
+		if c <= 0.03928 {
+			channels[i] = c / 12.92
+		} else {
+			channels[i] = math.Pow((c+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*channels[0] + 0.7152*channels[1] + 0.0722*channels[2]
+}
+
+// Warnings returns problems of a valid configuration that still make the
+// report hard to use, such as text colors barely distinguishable from the
+// page background. --strict-config turns them into errors.
+func (c *Config) Warnings() []string {
+	var warnings []string
+
+	if c.PDF.MinContrast > 0 {
+		background := [3]int{255, 255, 255}
+		if c.PDF.BackgroundColor != nil {
+			background = *c.PDF.BackgroundColor
+		}
+		colors := []struct {
+			name  string
+			color [3]int
+		}{
+			{"header_color", c.PDF.HeaderColor},
+			{"content_color", c.PDF.ContentColor},
+		}
+		for _, text := range colors {
+			if ratio := ContrastRatio(text.color, background); ratio < c.PDF.MinContrast {
+				warnings = append(warnings, fmt.Sprintf("contrast of %s %v against the background %v is %.2f:1, below the minimum of %.2f:1",
+					text.name, text.color, background, ratio, c.PDF.MinContrast))
+			}
+		}
+	}
+
+	return warnings
+}
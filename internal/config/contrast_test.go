@@ -0,0 +1,58 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	black, white := [3]int{0, 0, 0}, [3]int{255, 255, 255}
+	if got := ContrastRatio(black, white); math.Abs(got-21) > 1e-9 {
+		t.Errorf("black on white = %.2f, want 21", got)
+	}
+	if got := ContrastRatio(white, black); math.Abs(got-21) > 1e-9 {
+		t.Errorf("white on black = %.2f, want 21, independent of the order", got)
+	}
+	if got := ContrastRatio([3]int{120, 120, 120}, [3]int{120, 120, 120}); got != 1 {
+		t.Errorf("identical colors = %.2f, want 1", got)
+	}
+}
+
+func TestWarningsFlagLowContrast(t *testing.T) {
+	cfg := DefaultConfig()
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Fatalf("expected no warnings for the defaults, got %q", warnings)
+	}
+
+	cfg.PDF.ContentColor = [3]int{200, 200, 200}
+	warnings := cfg.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "content_color") {
+		t.Fatalf("expected the light gray content color flagged, got %q", warnings)
+	}
+
+	// The same color reads well on a dark background
+	cfg.PDF.BackgroundColor = &[3]int{20, 20, 20}
+	cfg.PDF.HeaderColor = [3]int{255, 255, 255}
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings on the dark background, got %q", warnings)
+	}
+
+	cfg.PDF.BackgroundColor = nil
+	cfg.PDF.MinContrast = 0
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Errorf("expected min_contrast 0 to disable the check, got %q", warnings)
+	}
+}
+
+func TestLowContrastFailsStrictLoad(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := writeFile(t, t.TempDir(), "config.json", `{"pdf": {"content_color": [230, 230, 230]}}`)
+
+	if _, err := Load(path); err != nil {
+		t.Errorf("expected low contrast to be only a warning, got %v", err)
+	}
+	if _, err := LoadStrict(path); err == nil || !strings.Contains(err.Error(), "content_color") {
+		t.Errorf("expected strict loading to reject the low contrast, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decode unmarshals JSON data into config, describing failures with the
+// line and column they occurred at and, for type errors, the offending field
+func decode(data []byte, config *Config, strict bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(config)
+	if err == nil {
+		// Reject trailing content after the top-level object
+		if _, err := decoder.Token(); err != io.EOF {
+			line, column := position(data, decoder.InputOffset())
+			return fmt.Errorf("line %d, column %d: unexpected content after configuration object", line, column)
+		}
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, column := position(data, syntaxErr.Offset)
+		return fmt.Errorf("line %d, column %d: %w", line, column, err)
+	case errors.As(err, &typeErr):
+		line, column := position(data, typeErr.Offset)
+		return fmt.Errorf("line %d, column %d: field %q must be %s, got %s",
+			line, column, typeErr.Field, typeName(typeErr.Type), typeErr.Value)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// The decoder stops after the enclosing object, so point at the
+		// last occurrence of the key before it instead
+		offset := decoder.InputOffset()
+		field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		if at := bytes.LastIndex(data[:offset], []byte(field)); at >= 0 {
+			offset = int64(at)
+		}
+		line, column := position(data, offset)
+		return fmt.Errorf("line %d, column %d: unknown field %s", line, column, field)
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("configuration file is empty")
+	default:
+		return err
+	}
+}
+
+// decodeYAML unmarshals a YAML document into config. Errors of the YAML
+// decoder already name the line they occurred at.
+func decodeYAML(data []byte, config *Config, strict bool) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(strict)
+
+	if err := decoder.Decode(config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("configuration file is empty")
+		}
+		return err
+	}
+
+	// Reject further documents after the configuration
+	var extra yaml.Node
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unexpected content after configuration document")
+	}
+	return nil
+}
+
+// typeName describes the JSON type expected for a Go type
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return t.String()
+	}
+}
+
+// position converts a byte offset into 1-based line and column numbers
+func position(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeUnknownKeyUnderStrictMode(t *testing.T) {
+	data := []byte("{\n  \"pdf\": {\n    \"title_alignn\": \"L\"\n  }\n}\n")
+
+	if err := decode(data, DefaultConfig(), false); err != nil {
+		t.Errorf("lenient decode rejected an unknown key: %v", err)
+	}
+
+	err := decode(data, DefaultConfig(), true)
+	if err == nil {
+		t.Fatal("strict decode accepted an unknown key")
+	}
+	if !strings.Contains(err.Error(), `unknown field "title_alignn"`) || !strings.HasPrefix(err.Error(), "line 3, column 5:") {
+		t.Errorf("error = %q, want the unknown field and its line", err)
+	}
+}
+
+func TestDecodeTypeErrorNamesField(t *testing.T) {
+	data := []byte("{\n  \"pdf\": {\n    \"margin_left\": \"wide\"\n  }\n}\n")
+
+	err := decode(data, DefaultConfig(), false)
+	if err == nil {
+		t.Fatal("decode accepted a string for a number")
+	}
+	want := `line 3, column 26: field "pdf.margin_left" must be a number, got string`
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestDecodeYAMLUnknownKeyUnderStrictMode(t *testing.T) {
+	data := []byte("# Comments are allowed\npdf:\n  title_alignn: L\n")
+
+	if err := decodeYAML(data, DefaultConfig(), false); err != nil {
+		t.Errorf("lenient decode rejected an unknown key: %v", err)
+	}
+
+	err := decodeYAML(data, DefaultConfig(), true)
+	if err == nil || !strings.Contains(err.Error(), "line 3") || !strings.Contains(err.Error(), "title_alignn") {
+		t.Errorf("error = %v, want the unknown field and its line", err)
+	}
+}
+
+func TestDecodeYAMLRejectsSecondDocument(t *testing.T) {
+	data := []byte("lang: en\n---\nlang: pl\n")
+
+	if err := decodeYAML(data, DefaultConfig(), false); err == nil {
+		t.Error("decode accepted a second YAML document")
+	}
+	if err := decodeYAML([]byte(""), DefaultConfig(), false); err == nil || err.Error() != "configuration file is empty" {
+		t.Errorf("error = %v, want an empty file error", err)
+	}
+}
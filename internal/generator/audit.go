@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+// conventionalSubject matches Conventional Commits subjects, e.g.
+// "feat(api)!: drop v1 endpoints"
+var conventionalSubject = regexp.MustCompile(`^[a-z]+(\([^()]+\))?!?: \S`)
+
+// Violation lists the audit policies a commit does not satisfy
+type Violation struct {
+	Commit   *git.Commit
+	Problems []string
+}
+
+// Audit checks the commits against the configured policies and returns the
+// commits violating at least one of them, in commit order
+func Audit(commits []*git.Commit, policies config.AuditConfig) ([]Violation, error) {
+	ticketPattern, err := regexp.Compile(policies.TicketPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit ticket pattern: %w", err)
+	}
+
+	var violations []Violation
+	for _, commit := range commits {
+		var problems []string
+		if policies.RequireSigned && commit.Signature == "" {
+			problems = append(problems, "commit is not signed")
+		}
+		if policies.RequireConventional && !conventionalSubject.MatchString(commit.Message) {
+			problems = append(problems, "subject does not follow the Conventional Commits format")
+		}
+		if max := policies.MaxSubjectLength; max > 0 {
+			if length := utf8.RuneCountInString(commit.Message); length > max {
+				problems = append(problems, fmt.Sprintf("subject is %d characters long (maximum %d)", length, max))
+			}
+		}
+		if policies.RequireTicket && commit.Ticket == "" &&
+			!ticketPattern.MatchString(commit.Message) && !ticketPattern.MatchString(commit.Body) {
+			problems = append(problems, "message does not reference a ticket")
+		}
+
+		if len(problems) > 0 {
+			violations = append(violations, Violation{Commit: commit, Problems: problems})
+		}
+	}
+	return violations, nil
+}
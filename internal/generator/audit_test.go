@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+func TestAuditUnsignedCommit(t *testing.T) {
+	unsigned := testCommit(3, "feat: add login")
+	signed := testCommit(4, "feat: add logout")
+	signed.Signature = "-----BEGIN PGP SIGNATURE-----"
+
+	violations, err := Audit([]*git.Commit{unsigned, signed}, config.AuditConfig{RequireSigned: true})
+	if err != nil {
+		t.Fatalf("audit failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Commit != unsigned {
+		t.Fatalf("expected only the unsigned commit to fail, got %+v", violations)
+	}
+	if problems := violations[0].Problems; len(problems) != 1 || problems[0] != "commit is not signed" {
+		t.Errorf("unexpected problems: %q", problems)
+	}
+}
+
+func TestAuditPolicies(t *testing.T) {
+	policies := config.DefaultConfig().Audit
+	policies.RequireConventional = true
+	policies.MaxSubjectLength = 20
+	policies.RequireTicket = true
+
+	good := testCommit(3, "fix: PROJ-12 typo")
+	bad := testCommit(4, "Fixed a typo somewhere in the docs")
+	ticketInBody := testCommit(5, "docs: fix typo")
+	ticketInBody.Body = "Refs PROJ-7"
+
+	violations, err := Audit([]*git.Commit{good, bad, ticketInBody}, policies)
+	if err != nil {
+		t.Fatalf("audit failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Commit != bad {
+		t.Fatalf("expected only the non-conforming commit to fail, got %+v", violations)
+	}
+	problems := strings.Join(violations[0].Problems, "\n")
+	for _, want := range []string{"Conventional Commits", "34 characters long (maximum 20)", "ticket"} {
+		if !strings.Contains(problems, want) {
+			t.Errorf("expected a problem mentioning %q, got:\n%s", want, problems)
+		}
+	}
+}
+
+func TestAuditInvalidTicketPattern(t *testing.T) {
+	if _, err := Audit(nil, config.AuditConfig{TicketPattern: "("}); err == nil {
+		t.Error("expected an error for an invalid ticket pattern")
+	}
+}
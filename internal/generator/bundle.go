@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BundleFile is a single file of a proof bundle
+type BundleFile struct {
+	Name    string
+	Content []byte
+}
+
+// ProofBundle renders everything a recipient needs to verify the report: the
+// PDF, the commit list as JSON, the commit set digest, the Merkle root and,
+// when any commit is signed, the commit signatures
+func ProofBundle(data *ReportData) ([]BundleFile, error) {
+	var report bytes.Buffer
+	if err := NewPDFGenerator().Write(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	jsonData := *data
+	jsonData.PrettyJSON = true
+	var commits bytes.Buffer
+	if err := (&JSONGenerator{}).Write(&jsonData, &commits); err != nil {
+		return nil, err
+	}
+
+	files := []BundleFile{
+		{Name: "report.pdf", Content: report.Bytes()},
+		{Name: "commits.json", Content: commits.Bytes()},
+		{Name: "digest.txt", Content: []byte(CommitSetDigest(data.Commits) + "\n")},
+		{Name: "merkle-root.txt", Content: []byte(CommitMerkleRoot(data.Commits) + "\n")},
+	}
+	if signatures, count := signaturesText(data.Commits); count > 0 {
+		files = append(files, BundleFile{Name: "signatures.asc", Content: []byte(signatures)})
+	}
+	return files, nil
+}
+
+// WriteBundleDir writes the bundle files into dir, creating it if needed
+func WriteBundleDir(files []BundleFile, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file.Name), file.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write bundle file %s: %w", file.Name, err)
+		}
+	}
+	return nil
+}
+
+// WriteBundleZip writes the bundle files into a zip archive at path
+func WriteBundleZip(files []BundleFile, path string) error {
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	modified := time.Now()
+	for _, file := range files {
+		entry, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:     file.Name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", file.Name, err)
+		}
+		if _, err := entry.Write(file.Content); err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", file.Name, err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle archive: %w", err)
+	}
+
+	if err := os.WriteFile(path, archive.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write bundle archive: %w", err)
+	}
+	return nil
+}
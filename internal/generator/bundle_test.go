@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestProofBundleContainsExpectedFiles(t *testing.T) {
+	signed := testCommit(4, "Signed change")
+	signed.Signature = "-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n"
+	data := testReportData(signed, testCommit(3, "Add login form"))
+
+	files, err := ProofBundle(data)
+	if err != nil {
+		t.Fatalf("ProofBundle failed: %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "proof.zip")
+	if err := WriteBundleZip(files, archive); err != nil {
+		t.Fatalf("WriteBundleZip failed: %v", err)
+	}
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+	defer reader.Close()
+
+	contents := make(map[string]string)
+	var names []string
+	for _, file := range reader.File {
+		entry, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(entry)
+		entry.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file.Name, err)
+		}
+		names = append(names, file.Name)
+		contents[file.Name] = string(content)
+	}
+	sort.Strings(names)
+
+	want := []string{"commits.json", "digest.txt", "merkle-root.txt", "report.pdf", "signatures.asc"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("bundle files = %q, want %q", names, want)
+	}
+	if contents["digest.txt"] != CommitSetDigest(data.Commits)+"\n" {
+		t.Errorf("digest.txt = %q", contents["digest.txt"])
+	}
+	if contents["merkle-root.txt"] != CommitMerkleRoot(data.Commits)+"\n" {
+		t.Errorf("merkle-root.txt = %q", contents["merkle-root.txt"])
+	}
+
+	// Without signed commits there is no signatures file, and the directory
+	// layout matches the archive
+	files, err = ProofBundle(testReportData(testCommit(3, "Add login form")))
+	if err != nil {
+		t.Fatalf("ProofBundle failed: %v", err)
+	}
+	dir := filepath.Join(t.TempDir(), "proof")
+	if err := WriteBundleDir(files, dir); err != nil {
+		t.Fatalf("WriteBundleDir failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read bundle directory: %v", err)
+	}
+	names = nil
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := want[:4]; !reflect.DeepEqual(names, want) {
+		t.Errorf("bundle directory = %q, want %q", names, want)
+	}
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"git-report-generator/internal/git"
+)
+
+// proofKeywordPrefix marks the PDF keywords holding the embedded proof
+const proofKeywordPrefix = "git-report-generator-proof:"
+
+// proofKeywordsPattern matches the proof in the PDF document information
+var proofKeywordsPattern = regexp.MustCompile(`/Keywords \(` + regexp.QuoteMeta(proofKeywordPrefix) + `([^)]*)\)`)
+
+// CommitSetDigest returns a SHA-256 digest over the sorted full hashes of the
+// commits, independent of the order they are listed in
+func CommitSetDigest(commits []*git.Commit) string {
+	hashes := make([]string, len(commits))
+	for i, commit := range commits {
+		hashes[i] = commit.Hash
+	}
+	sort.Strings(hashes)
+
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "\n")))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// CommitMerkleRoot returns the root of a SHA-256 Merkle tree whose leaves are
+// the sorted full commit hashes, so the inclusion of a single commit can be
+// proven without disclosing the others. A node without a sibling is paired
+// with itself.
+func CommitMerkleRoot(commits []*git.Commit) string {
+	hashes := make([]string, len(commits))
+	for i, commit := range commits {
+		hashes[i] = commit.Hash
+	}
+	sort.Strings(hashes)
+
+	level := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		sum := sha256.Sum256([]byte(hash))
+		level[i] = sum[:]
+	}
+	if len(level) == 0 {
+		sum := sha256.Sum256(nil)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			sum := sha256.Sum256(append(append([]byte{}, level[i]...), right...))
+			next = append(next, sum[:])
+		}
+		level = next
+	}
+	return "sha256:" + hex.EncodeToString(level[0])
+}
+
+// proofKeywords encodes the selection parameters and commit set digest for
+// embedding in the PDF metadata. With redacted emails the author filter is
+// only recorded as digests, so the metadata does not disclose the addresses.
+func proofKeywords(data *ReportData) string {
+	values := url.Values{}
+	for key, vals := range data.ProofParams {
+		values[key] = vals
+	}
+	if data.Config.Privacy.RedactEmails {
+		for _, email := range values["author"] {
+			values.Add("author_sha256", EmailDigest(email))
+		}
+		values.Del("author")
+	}
+	values.Set("digest", CommitSetDigest(data.Commits))
+	return proofKeywordPrefix + values.Encode()
+}
+
+// ReadProof returns the selection parameters and commit set digest embedded
+// in a PDF report
+func ReadProof(pdfPath string) (url.Values, error) {
+	content, err := os.ReadFile(pdfPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	match := proofKeywordsPattern.FindSubmatch(content)
+	if match == nil {
+		return nil, fmt.Errorf("no checksum metadata found in %s", pdfPath)
+	}
+
+	values, err := url.ParseQuery(string(match[1]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checksum metadata: %w", err)
+	}
+	if values.Get("digest") == "" {
+		return nil, fmt.Errorf("checksum metadata in %s has no digest", pdfPath)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,37 @@
+package generator
+
+import "strings"
+
+// codeFence opens and closes a code block in a commit body
+const codeFence = "```"
+
+// splitCodeBlocks separates the fenced code blocks of a commit body from its
+// prose. The prose is flattened like commit descriptions, joining non-empty
+// lines with spaces, while code blocks keep their lines and indentation.
+// An unterminated fence extends to the end of the body.
+func splitCodeBlocks(body string) (prose string, blocks []string) {
+	var proseLines, code []string
+	inCode := false
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), codeFence) {
+			if inCode {
+				blocks = append(blocks, strings.Join(code, "\n"))
+				code = nil
+			}
+			inCode = !inCode
+			continue
+		}
+
+		if inCode {
+			code = append(code, strings.TrimRight(line, " \t\r"))
+		} else if trimmed := strings.TrimSpace(line); trimmed != "" {
+			proseLines = append(proseLines, trimmed)
+		}
+	}
+	if inCode && len(code) > 0 {
+		blocks = append(blocks, strings.Join(code, "\n"))
+	}
+
+	return strings.Join(proseLines, " "), blocks
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCodeBlocks(t *testing.T) {
+	body := "Explain the fix\nin two lines\n\n```go\nif x := 1; x > 0 {\n\treturn\n}\n```\nTrailing note\n```\nunterminated"
+	prose, blocks := splitCodeBlocks(body)
+
+	if prose != "Explain the fix in two lines Trailing note" {
+		t.Errorf("prose = %q", prose)
+	}
+	want := []string{"if x := 1; x > 0 {\n\treturn\n}", "unterminated"}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("blocks = %q, want %q", blocks, want)
+	}
+}
+
+func TestFencedBlockRendersInMonospace(t *testing.T) {
+	commit := testCommit(3, "Fix retry loop")
+	commit.Body = "Stop after three attempts\n\n```\nattempts := 3\n```"
+
+	for _, enabled := range []bool{false, true} {
+		data := testReportData(commit)
+		data.Config.PDF.RenderCodeBlocks = enabled
+		content := renderPDF(t, data)
+
+		// Only the Courier core font writes single-byte strings, text in the
+		// embedded UTF-8 fonts is UTF-16 encoded
+		streams := bytes.Join(pdfPageStreams(t, content), nil)
+		if got := bytes.Contains(streams, []byte("(attempts := 3)Tj")); got != enabled {
+			t.Errorf("render code blocks %v: monospace block drawn = %v", enabled, got)
+		}
+		if enabled && !strings.Contains(pdfJoinedText(t, content), "Stop after three attempts") {
+			t.Error("prose of the body missing from the description")
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"git-report-generator/internal/config"
+)
+
+// minTextContrast is the WCAG AA contrast ratio author colors keep against
+// the white page
+const minTextContrast = 4.5
+
+// authorColor returns the color the author's labels are drawn in: the one
+// configured under authors[email].color, or one derived from the email so it
+// stays the same across runs. Either is darkened until it reads on white.
+func authorColor(authors map[string]config.AuthorConfig, email string) [3]int {
+	email = strings.ToLower(email)
+	for key, author := range authors {
+		if strings.ToLower(key) == email && author.Color != nil {
+			return readableOnWhite(*author.Color)
+		}
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(email))
+	// Mix the hash bits so similar emails still land on distant hues
+	hue := float64((h.Sum32() * 2654435761 >> 16) % 360)
+	return readableOnWhite(hslToRGB(hue, 0.65, 0.4))
+}
+
+// readableOnWhite darkens the color in small steps until its contrast
+// against white reaches minTextContrast
+func readableOnWhite(color [3]int) [3]int {
+	white := [3]int{255, 255, 255}
+	for config.ContrastRatio(color, white) < minTextContrast {
+		for i := range color {
+			color[i] = color[i] * 9 / 10
+		}
+	}
+	return color
+}
+
+// hslToRGB converts a hue in degrees and saturation and lightness in [0, 1]
+// to RGB values 0-255
+func hslToRGB(hue, saturation, lightness float64) [3]int {
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return [3]int{
+		int(math.Round((r + m) * 255)),
+		int(math.Round((g + m) * 255)),
+		int(math.Round((b + m) * 255)),
+	}
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/config"
+)
+
+func TestAuthorColorIsStable(t *testing.T) {
+	// A hash that changed between runs or releases would change this color
+	if got, want := authorColor(nil, "jan@example.com"), [3]int{135, 105, 28}; got != want {
+		t.Errorf("authorColor(jan@example.com) = %v, want %v", got, want)
+	}
+	if authorColor(nil, "Jan@Example.com") != authorColor(nil, "jan@example.com") {
+		t.Error("expected the color to ignore the case of the email")
+	}
+
+	white := [3]int{255, 255, 255}
+	for i := 0; i < 50; i++ {
+		email := fmt.Sprintf("dev%d@example.com", i)
+		if ratio := config.ContrastRatio(authorColor(nil, email), white); ratio < minTextContrast {
+			t.Errorf("color of %s has contrast %.2f against white, want at least %.1f", email, ratio, minTextContrast)
+		}
+	}
+}
+
+func TestConfiguredAuthorColor(t *testing.T) {
+	authors := map[string]config.AuthorConfig{
+		"Anna@example.com": {Color: &[3]int{0, 0, 128}},
+		"pale@example.com": {Color: &[3]int{255, 255, 0}},
+	}
+	if got := authorColor(authors, "anna@example.com"); got != [3]int{0, 0, 128} {
+		t.Errorf("expected the configured color, got %v", got)
+	}
+	pale := authorColor(authors, "pale@example.com")
+	if pale == [3]int{255, 255, 0} || config.ContrastRatio(pale, [3]int{255, 255, 255}) < minTextContrast {
+		t.Errorf("expected the pale configured color darkened to be readable, got %v", pale)
+	}
+}
+
+func TestAuthorColorsInGroupHeadings(t *testing.T) {
+	data := testReportData(testCommit(3, "one"))
+	data.Config.PDF.GroupBy = config.GroupByAuthor
+	data.Config.PDF.AuthorColors = true
+	data.Config.Authors = map[string]config.AuthorConfig{"jan@example.com": {Color: &[3]int{0, 0, 128}}}
+	data.Commits = GroupCommits(data)
+
+	const navy = "0.000 0.000 0.502 rg"
+	if page := string(pdfPageStreams(t, renderPDF(t, data))[0]); !strings.Contains(page, navy) {
+		t.Error("expected the heading drawn in the configured color")
+	}
+
+	data.Config.PDF.AuthorColors = false
+	if page := string(pdfPageStreams(t, renderPDF(t, data))[0]); strings.Contains(page, navy) {
+		t.Error("expected no author color when disabled")
+	}
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"fmt"
+	"image"
+	// Decoders of the image formats gofpdf can embed
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// generateCover renders the title page: the report title, repository and
+// period, over a full-bleed cover image when one is configured
+func (g *PDFGenerator) generateCover(data *ReportData) error {
+	header, err := renderHeader(data)
+	if err != nil {
+		return err
+	}
+
+	textColor := [3]int{0, 0, 0}
+	if path := data.Config.PDF.CoverImage; path != "" {
+		dark, err := g.drawCoverImage(path)
+		if err != nil {
+			return err
+		}
+		if dark {
+			textColor = [3]int{255, 255, 255}
+		}
+	}
+
+	_, pageHeight := g.pdf.GetPageSize()
+	sizes := data.Config.PDF.FontSizes
+	g.pdf.SetTextColor(textColor[0], textColor[1], textColor[2])
+
+	g.pdf.SetXY(g.leftMargin(), pageHeight*0.35)
+	g.pdf.SetFont(fontName, "B", sizes.Title*1.6)
+	g.pdf.MultiCell(g.availableWidth(), sizes.Title*0.9, header.Title, "", "C", false)
+	g.pdf.Ln(8)
+
+	g.pdf.SetFont(fontName, "", sizes.Body+2)
+	g.pdf.CellFormat(g.availableWidth(), 8, data.RepositoryName, "", 1, "C", false, 0, "")
+	g.pdf.CellFormat(g.availableWidth(), 8, data.periodText(), "", 1, "C", false, 0, "")
+
+	g.pdf.SetTextColor(0, 0, 0)
+	return nil
+}
+
+// drawCoverImage scales the image to cover the whole page, cropping what
+// overflows its aspect ratio, and reports whether the image is dark enough
+// to need light text on top
+func (g *PDFGenerator) drawCoverImage(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open cover image: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode cover image %s: %w", path, err)
+	}
+
+	pageWidth, pageHeight := g.pdf.GetPageSize()
+	bounds := img.Bounds()
+	scale := math.Max(pageWidth/float64(bounds.Dx()), pageHeight/float64(bounds.Dy()))
+	width, height := float64(bounds.Dx())*scale, float64(bounds.Dy())*scale
+
+	g.pdf.ClipRect(0, 0, pageWidth, pageHeight, false)
+	g.pdf.ImageOptions(path, (pageWidth-width)/2, (pageHeight-height)/2, width, height,
+		false, gofpdf.ImageOptions{ReadDpi: false}, 0, "")
+	g.pdf.ClipEnd()
+	if err := g.pdf.Error(); err != nil {
+		return false, fmt.Errorf("failed to embed cover image %s: %w", path, err)
+	}
+
+	return imageLuminance(img) < 0.5, nil
+}
+
+// imageLuminance returns the mean relative luminance (0 black to 1 white) of
+// a grid of sampled pixels
+func imageLuminance(img image.Image) float64 {
+	bounds := img.Bounds()
+	const samples = 32
+	var total float64
+	for i := 0; i < samples; i++ {
+		for j := 0; j < samples; j++ {
+			x := bounds.Min.X + (2*i+1)*bounds.Dx()/(2*samples)
+			y := bounds.Min.Y + (2*j+1)*bounds.Dy()/(2*samples)
+			r, g, b, _ := img.At(x, y).RGBA()
+			total += (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 0xffff
+		}
+	}
+	return total / (samples * samples)
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePNG writes a single-colored PNG image of the given size
+func writePNG(t *testing.T, path string, width, height int, fill color.Color) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write image: %v", err)
+	}
+}
+
+func TestCoverImage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cover.png")
+	writePNG(t, path, 40, 60, color.RGBA{10, 20, 40, 255})
+
+	data := testReportData(testCommit(3, "one"))
+	data.Config.PDF.CoverPage = true
+	data.Config.PDF.CoverImage = path
+	content := renderPDF(t, data)
+
+	cover := string(pdfPageStreams(t, content)[0])
+	if !strings.Contains(cover, " Do") {
+		t.Error("expected the cover image drawn on the first page")
+	}
+	// Light text over the dark image, drawn after it
+	if !strings.Contains(cover[strings.Index(cover, " Do"):], "1.000 g") {
+		t.Error("expected white title text over the dark cover image")
+	}
+	items := pdfText(t, content)
+	title := findText(t, items, "Protokół")
+	// The title starts at 35% of the A4 page height from the top
+	const pageHeight = 841.89
+	if title.y > pageHeight*0.65 || title.y < pageHeight*0.4 {
+		t.Errorf("expected the title in the upper middle of the cover, got y %.1f", title.y)
+	}
+}
+
+func TestLightCoverImageKeepsDarkText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cover.png")
+	writePNG(t, path, 40, 60, color.RGBA{250, 250, 240, 255})
+
+	data := testReportData(testCommit(3, "one"))
+	data.Config.PDF.CoverPage = true
+	data.Config.PDF.CoverImage = path
+
+	cover := string(pdfPageStreams(t, renderPDF(t, data))[0])
+	if strings.Contains(cover[strings.Index(cover, " Do"):], "1.000 g") {
+		t.Error("expected dark title text over the light cover image")
+	}
+}
+
+func TestMissingCoverImage(t *testing.T) {
+	data := testReportData(testCommit(3, "one"))
+	data.Config.PDF.CoverPage = true
+	data.Config.PDF.CoverImage = filepath.Join(t.TempDir(), "missing.png")
+
+	err := NewPDFGenerator().Write(data, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "cover image") {
+		t.Errorf("expected a cover image error, got %v", err)
+	}
+}
+
+func TestImageLuminance(t *testing.T) {
+	black := image.NewGray(image.Rect(0, 0, 4, 4))
+	white := image.NewGray(image.Rect(0, 0, 4, 4))
+	for i := range white.Pix {
+		white.Pix[i] = 255
+	}
+	if got := imageLuminance(black); got != 0 {
+		t.Errorf("expected 0 for black, got %v", got)
+	}
+	if got := imageLuminance(white); got < 0.99 {
+		t.Errorf("expected 1 for white, got %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// utf8BOM makes Excel read the CSV as UTF-8 instead of the local code page
+const utf8BOM = "\xef\xbb\xbf"
+
+// CSVGenerator writes the report commits as CSV, one row per commit, for
+// spreadsheets and invoicing tools
+type CSVGenerator struct{}
+
+func init() {
+	Register(&CSVGenerator{})
+}
+
+// Format returns the output format name of the generator
+func (g *CSVGenerator) Format() string {
+	return "csv"
+}
+
+// Write renders the commits as RFC 4180 CSV with a header row, preceded by
+// a UTF-8 BOM when CSVBOM is set
+func (g *CSVGenerator) Write(data *ReportData, out io.Writer) error {
+	if data.CSVBOM {
+		if _, err := io.WriteString(out, utf8BOM); err != nil {
+			return fmt.Errorf("failed to write CSV report: %w", err)
+		}
+	}
+
+	w := csv.NewWriter(out)
+	w.UseCRLF = true
+	w.Write([]string{"SHA", "Date", "Author", "AuthorEmail", "Message", "Description"})
+	for _, commit := range data.Commits {
+		w.Write([]string{
+			commit.SHA,
+			data.displayTime(commit.Date).Format("2006-01-02"),
+			commit.Author,
+			data.displayEmail(commit.AuthorEmail),
+			commit.Message,
+			commit.Description,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return nil
+}
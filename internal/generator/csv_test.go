@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVRowsAndQuoting(t *testing.T) {
+	commit := testCommit(3, "Fix login, logout")
+	commit.Description = "First line\nSecond \"quoted\" line"
+	data := testReportData(commit, testCommit(2, "Zażółć gęślą jaźń"))
+
+	var buf bytes.Buffer
+	if err := (&CSVGenerator{}).Write(data, &buf); err != nil {
+		t.Fatalf("failed to render CSV: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\"Fix login, logout\",\"First line\r\nSecond \"\"quoted\"\" line\"\r\n") {
+		t.Errorf("fields are not quoted per RFC 4180:\n%s", buf.String())
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	want := [][]string{
+		{"SHA", "Date", "Author", "AuthorEmail", "Message", "Description"},
+		{"33333333", "2024-01-03", "Jan Kowalski", "jan@example.com", "Fix login, logout", "First line\nSecond \"quoted\" line"},
+		{"22222222", "2024-01-02", "Jan Kowalski", "jan@example.com", "Zażółć gęślą jaźń", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestCSVByteOrderMark(t *testing.T) {
+	data := testReportData(testCommit(2, "Add login form"))
+
+	var buf bytes.Buffer
+	if err := (&CSVGenerator{}).Write(data, &buf); err != nil {
+		t.Fatalf("failed to render CSV: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "SHA,") {
+		t.Errorf("expected no BOM by default, got %q", buf.String()[:8])
+	}
+
+	data.CSVBOM = true
+	buf.Reset()
+	if err := (&CSVGenerator{}).Write(data, &buf); err != nil {
+		t.Fatalf("failed to render CSV: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), utf8BOM+"SHA,") {
+		t.Errorf("expected a UTF-8 BOM, got %q", buf.String()[:8])
+	}
+}
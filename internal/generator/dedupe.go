@@ -0,0 +1,28 @@
+package generator
+
+import "git-report-generator/internal/git"
+
+// DedupeByMessage collapses commits with identical subject and description,
+// such as cherry-picks, keeping the earliest one of each. The order of the
+// remaining commits is kept. It returns the remaining commits and the number
+// of commits collapsed.
+func DedupeByMessage(commits []*git.Commit) ([]*git.Commit, int) {
+	type message struct{ subject, description string }
+
+	earliest := make(map[message]*git.Commit)
+	for _, commit := range commits {
+		key := message{commit.Message, commit.Description}
+		if kept, ok := earliest[key]; !ok || commit.Date.Before(kept.Date) {
+			earliest[key] = commit
+		}
+	}
+
+	deduped := make([]*git.Commit, 0, len(earliest))
+	for _, commit := range commits {
+		if earliest[message{commit.Message, commit.Description}] == commit {
+			deduped = append(deduped, commit)
+		}
+	}
+
+	return deduped, len(commits) - len(deduped)
+}
@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/git"
+)
+
+func TestDedupeByMessageKeepsEarliest(t *testing.T) {
+	picked := testCommit(6, "Fix login redirect")
+	other := testCommit(5, "Add login form")
+	original := testCommit(4, "Fix login redirect")
+	sameSubject := testCommit(3, "Fix login redirect")
+	sameSubject.Description = "Different body"
+
+	deduped, collapsed := DedupeByMessage([]*git.Commit{picked, other, original, sameSubject})
+	if want := []*git.Commit{other, original, sameSubject}; !reflect.DeepEqual(deduped, want) {
+		t.Errorf("deduped = %q, want the form, the original fix and the fix with another body", subjectsOf(deduped))
+	}
+	if collapsed != 1 {
+		t.Errorf("collapsed = %d, want 1", collapsed)
+	}
+
+	data := testReportData(deduped...)
+	data.Collapsed = collapsed
+	if text := pdfJoinedText(t, renderPDF(t, data)); !strings.Contains(text, "Pominięte duplikaty (ta sama treść): 1") {
+		t.Errorf("collapse count missing from the summary:\n%s", text)
+	}
+}
@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// docxContentTypes declares the parts of the DOCX package
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+
+// docxRels points the package at its main document
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+// docxAlignments maps the PDF title alignments to WordprocessingML values
+var docxAlignments = map[string]string{"L": "left", "C": "center", "R": "right"}
+
+// DOCXGenerator renders reports as editable Word documents, laid out like
+// the PDF: the header as paragraphs followed by the commit table
+type DOCXGenerator struct{}
+
+func init() {
+	Register(NewDOCXGenerator())
+}
+
+// NewDOCXGenerator creates a new DOCX generator
+func NewDOCXGenerator() *DOCXGenerator {
+	return &DOCXGenerator{}
+}
+
+// Format returns the output format name of the generator
+func (g *DOCXGenerator) Format() string {
+	return "docx"
+}
+
+// Write renders the report as a DOCX package to the given writer
+func (g *DOCXGenerator) Write(data *ReportData, out io.Writer) error {
+	document, err := g.document(data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"word/document.xml", document},
+	}
+	for _, part := range parts {
+		w, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to DOCX: %w", part.name, err)
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return fmt.Errorf("failed to write %s to DOCX: %w", part.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish DOCX: %w", err)
+	}
+
+	if _, err := buf.WriteTo(out); err != nil {
+		return fmt.Errorf("failed to write DOCX report: %w", err)
+	}
+	return nil
+}
+
+// document builds word/document.xml with the header, commit table, summary
+// and disclaimer
+func (g *DOCXGenerator) document(data *ReportData) (string, error) {
+	header, err := renderHeader(data)
+	if err != nil {
+		return "", err
+	}
+	disclaimer, err := renderTemplate("disclaimer", data.Config.Footer.Disclaimer, headerTemplateData(data))
+	if err != nil {
+		return "", err
+	}
+
+	sizes := data.Config.PDF.FontSizes
+	var body strings.Builder
+	body.WriteString(docxParagraph(header.DateLine, "", sizes.Body, false, false))
+	body.WriteString(docxParagraph(header.Title, docxAlignments[data.Config.PDF.TitleAlign], sizes.Title, true, false))
+	for _, line := range strings.Split(header.Body, "\n") {
+		body.WriteString(docxParagraph(line, "", sizes.Body, false, false))
+	}
+
+	if len(data.Commits) == 0 {
+		body.WriteString(docxParagraph("Brak commitów w podanym okresie.", "", sizes.Body, false, true))
+	} else {
+		body.WriteString(g.commitTable(data))
+	}
+
+	if data.Config.PDF.ShowSummary {
+		body.WriteString(docxParagraph(fmt.Sprintf("Łączna liczba commitów: %s", data.formatNumber(len(data.Commits))), "", sizes.Summary, true, false))
+		if len(data.AuthorEmails) > 1 {
+			body.WriteString(docxParagraph("Autorzy:", "", sizes.Summary, false, false))
+			for _, count := range data.authorCounts() {
+				body.WriteString(docxParagraph(fmt.Sprintf("  %s: %s", count.label, data.formatNumber(count.commits)), "", sizes.Summary, false, false))
+			}
+		} else if author := data.summaryAuthor(); author != "" {
+			body.WriteString(docxParagraph("Autor: "+author, "", sizes.Summary, false, false))
+		}
+	}
+
+	if disclaimer != "" {
+		for _, line := range strings.Split(disclaimer, "\n") {
+			body.WriteString(docxParagraph(line, "", sizes.Footer, false, true))
+		}
+	}
+	body.WriteString(docxParagraph(fmt.Sprintf("Suma kontrolna commitów: %s", CommitSetDigest(data.Commits)), "", sizes.Footer, false, true))
+
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="0" w:footer="0" w:gutter="0"/></w:sectPr></w:body></w:document>`, nil
+}
+
+// commitTable renders the commits as a bordered Word table with the same
+// columns as the PDF table
+func (g *DOCXGenerator) commitTable(data *ReportData) string {
+	size := data.Config.PDF.FontSizes.Table
+	withTickets := data.Config.CommitParsing.ShowTicketColumn
+	withStats := data.Config.Stats.WithStats
+
+	headings := []string{"Data", "SHA"}
+	if withTickets {
+		headings = append(headings, "Zgłoszenie")
+	}
+	headings = append(headings, "Opis")
+	if withStats {
+		headings = append(headings, data.linesHeading())
+	}
+
+	var b strings.Builder
+	b.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(&b, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="000000"/>`, side)
+	}
+	b.WriteString(`</w:tblBorders></w:tblPr>`)
+
+	b.WriteString(`<w:tr><w:trPr><w:tblHeader/></w:trPr>`)
+	for _, heading := range headings {
+		b.WriteString(docxCell(docxParagraph(heading, "center", size, true, false), "DCDCDC"))
+	}
+	b.WriteString(`</w:tr>`)
+
+	for _, commit := range data.Commits {
+		b.WriteString(`<w:tr>`)
+		b.WriteString(docxCell(docxParagraph(data.displayTime(commit.Date).Format("2006-01-02"), "center", size, false, false), ""))
+		b.WriteString(docxCell(docxParagraph(commit.SHA, "center", size, false, false), ""))
+		if withTickets {
+			b.WriteString(docxCell(docxParagraph(commit.Ticket, "center", size, false, false), ""))
+		}
+
+		description := docxParagraph(commit.Message, "", size, true, false)
+		if commit.Description != "" {
+			description += docxParagraph(commit.Description, "", size, false, false)
+		}
+		if commit.Note != "" {
+			description += docxParagraph("Notatka: "+commit.Note, "", size, false, true)
+		}
+		b.WriteString(docxCell(description, ""))
+
+		if withStats {
+			b.WriteString(docxCell(docxParagraph(data.linesText(commit), "right", size, false, false), ""))
+		}
+		b.WriteString(`</w:tr>`)
+	}
+
+	b.WriteString(`</w:tbl>`)
+	return b.String()
+}
+
+// docxParagraph renders a single paragraph; the size is given in points
+func docxParagraph(text, align string, size float64, bold, italic bool) string {
+	var props strings.Builder
+	if bold {
+		props.WriteString(`<w:b/>`)
+	}
+	if italic {
+		props.WriteString(`<w:i/>`)
+	}
+	fmt.Fprintf(&props, `<w:sz w:val="%d"/>`, int(size*2))
+
+	var p strings.Builder
+	p.WriteString(`<w:p>`)
+	if align != "" {
+		fmt.Fprintf(&p, `<w:pPr><w:jc w:val="%s"/></w:pPr>`, align)
+	}
+	fmt.Fprintf(&p, `<w:r><w:rPr>%s</w:rPr><w:t xml:space="preserve">%s</w:t></w:r></w:p>`, props.String(), docxEscape(text))
+	return p.String()
+}
+
+// docxCell wraps paragraphs in a table cell, shaded when fill is set
+func docxCell(content, fill string) string {
+	shading := ""
+	if fill != "" {
+		shading = fmt.Sprintf(`<w:tcPr><w:shd w:val="clear" w:color="auto" w:fill="%s"/></w:tcPr>`, fill)
+	}
+	return `<w:tc>` + shading + content + `</w:tc>`
+}
+
+// docxEscape escapes text for use in XML character data
+func docxEscape(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
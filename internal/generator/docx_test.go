@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// docxTestParagraph is a paragraph of word/document.xml
+type docxTestParagraph struct {
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// text joins the runs of the paragraph
+func (p docxTestParagraph) text() string {
+	var b strings.Builder
+	for _, run := range p.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// docxTestDocument is the part of word/document.xml the tests inspect
+type docxTestDocument struct {
+	Body struct {
+		Paragraphs []docxTestParagraph `xml:"p"`
+		Tables     []struct {
+			Rows []struct {
+				Cells []struct {
+					Paragraphs []docxTestParagraph `xml:"p"`
+				} `xml:"tc"`
+			} `xml:"tr"`
+		} `xml:"tbl"`
+	} `xml:"body"`
+}
+
+// renderDOCX renders the report as DOCX and returns its parts by name
+func renderDOCX(t *testing.T, data *ReportData) map[string][]byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := NewDOCXGenerator().Write(data, &buf); err != nil {
+		t.Fatalf("failed to render DOCX: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to unzip DOCX: %v", err)
+	}
+	parts := make(map[string][]byte)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file.Name, err)
+		}
+		parts[file.Name] = content
+	}
+	return parts
+}
+
+func TestDOCXPackage(t *testing.T) {
+	parts := renderDOCX(t, testReportData(testCommit(3, "one")))
+
+	var names []string
+	for name := range parts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if want := []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("parts = %q, want %q", names, want)
+	}
+
+	var types struct {
+		Defaults []struct {
+			Extension   string `xml:"Extension,attr"`
+			ContentType string `xml:"ContentType,attr"`
+		} `xml:"Default"`
+		Overrides []struct {
+			PartName    string `xml:"PartName,attr"`
+			ContentType string `xml:"ContentType,attr"`
+		} `xml:"Override"`
+	}
+	if err := xml.Unmarshal(parts["[Content_Types].xml"], &types); err != nil {
+		t.Fatalf("invalid [Content_Types].xml: %v", err)
+	}
+	defaults := make(map[string]string)
+	for _, d := range types.Defaults {
+		defaults[d.Extension] = d.ContentType
+	}
+	if defaults["rels"] != "application/vnd.openxmlformats-package.relationships+xml" {
+		t.Errorf("rels parts have content type %q", defaults["rels"])
+	}
+	const mainType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"
+	if len(types.Overrides) != 1 || types.Overrides[0].PartName != "/word/document.xml" || types.Overrides[0].ContentType != mainType {
+		t.Errorf("expected the main document override, got %+v", types.Overrides)
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Type   string `xml:"Type,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(parts["_rels/.rels"], &rels); err != nil {
+		t.Fatalf("invalid _rels/.rels: %v", err)
+	}
+	if len(rels.Relationships) != 1 {
+		t.Fatalf("expected a single package relationship, got %+v", rels.Relationships)
+	}
+	rel := rels.Relationships[0]
+	if rel.Type != "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" {
+		t.Errorf("relationship type = %q, want officeDocument", rel.Type)
+	}
+	if _, ok := parts[rel.Target]; !ok {
+		t.Errorf("relationship target %q is not in the package", rel.Target)
+	}
+	// The document references no images or styles, so it has no relationships of its own
+	if _, ok := parts["word/_rels/document.xml.rels"]; ok {
+		t.Error("unexpected word/_rels/document.xml.rels")
+	}
+}
+
+func TestDOCXDocumentContent(t *testing.T) {
+	second := testCommit(5, "Escape <tags> & more")
+	second.Description = "Details of the change"
+	parts := renderDOCX(t, testReportData(second, testCommit(3, "Add login form")))
+
+	// Every token must parse, so the document opens in Word
+	decoder := xml.NewDecoder(bytes.NewReader(parts["word/document.xml"]))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("word/document.xml is not well-formed: %v", err)
+		}
+	}
+
+	var document docxTestDocument
+	if err := xml.Unmarshal(parts["word/document.xml"], &document); err != nil {
+		t.Fatalf("failed to parse word/document.xml: %v", err)
+	}
+	var paragraphs []string
+	for _, p := range document.Body.Paragraphs {
+		paragraphs = append(paragraphs, p.text())
+	}
+	text := strings.Join(paragraphs, "\n")
+	for _, want := range []string{
+		"Some City, 2024-01-01 - 2024-01-31",
+		"Protokół odbioru prac programistycznych",
+		"Repozytorium: repo",
+		"Łączna liczba commitów: 2",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the document paragraphs:\n%s", want, text)
+		}
+	}
+
+	if len(document.Body.Tables) != 1 {
+		t.Fatalf("expected one commit table, got %d", len(document.Body.Tables))
+	}
+	var rows [][]string
+	for _, row := range document.Body.Tables[0].Rows {
+		var cells []string
+		for _, cell := range row.Cells {
+			var lines []string
+			for _, p := range cell.Paragraphs {
+				lines = append(lines, p.text())
+			}
+			cells = append(cells, strings.Join(lines, "\n"))
+		}
+		rows = append(rows, cells)
+	}
+	want := [][]string{
+		{"Data", "SHA", "Opis"},
+		{"2024-01-05", "55555555", "Escape <tags> & more\nDetails of the change"},
+		{"2024-01-03", "33333333", "Add login form"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("table rows = %q, want %q", rows, want)
+	}
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+// effortEstimates returns the estimated hours of work per commit hash. Each
+// author's commits are replayed in time order: a commit made within the
+// session gap of the author's previous one gets the time since it, any other
+// commit starts a session and gets the configured first commit time. Every
+// estimate is rounded as configured.
+func effortEstimates(commits []*git.Commit, estimation config.EstimationConfig) map[string]float64 {
+	byAuthor := make(map[string][]*git.Commit)
+	for _, commit := range commits {
+		key := authorKey(commit)
+		byAuthor[key] = append(byAuthor[key], commit)
+	}
+
+	sessionGap := time.Duration(estimation.SessionGapMinutes) * time.Minute
+	firstCommit := float64(estimation.FirstCommitMinutes) / 60
+
+	estimates := make(map[string]float64, len(commits))
+	for _, authored := range byAuthor {
+		sorted := append([]*git.Commit(nil), authored...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+		for i, commit := range sorted {
+			hours := firstCommit
+			if i > 0 {
+				if gap := commit.Date.Sub(sorted[i-1].Date); gap <= sessionGap {
+					hours = gap.Hours()
+				}
+			}
+			estimates[commit.Hash] = roundEffort(hours, estimation.Rounding)
+		}
+	}
+	return estimates
+}
+
+// totalEffort sums the effort estimates of the commits
+func totalEffort(commits []*git.Commit, estimates map[string]float64) float64 {
+	var total float64
+	for _, commit := range commits {
+		total += estimates[commit.Hash]
+	}
+	return total
+}
+
+// roundEffort rounds hours up to the next quarter hour or full hour, matching
+// timesheet granularity, or returns them unchanged without a rounding mode
+func roundEffort(hours float64, rounding string) float64 {
+	var steps float64
+	switch rounding {
+	case config.RoundingQuarterHour:
+		steps = 4
+	case config.RoundingHour:
+		steps = 1
+	default:
+		return hours
+	}
+	// Tolerate float noise so exact multiples are not rounded up a step
+	return math.Ceil(hours*steps-1e-9) / steps
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+func TestRoundEffort(t *testing.T) {
+	tests := []struct {
+		hours    float64
+		rounding string
+		want     float64
+	}{
+		{1.1, config.RoundingQuarterHour, 1.25},
+		{1.25, config.RoundingQuarterHour, 1.25},
+		{0.01, config.RoundingQuarterHour, 0.25},
+		{1.1, config.RoundingHour, 2},
+		{2, config.RoundingHour, 2},
+		{1.1, "", 1.1},
+	}
+	for _, tt := range tests {
+		if got := roundEffort(tt.hours, tt.rounding); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("roundEffort(%v, %q) = %v, want %v", tt.hours, tt.rounding, got, tt.want)
+		}
+	}
+}
+
+func TestEffortEstimatesRounding(t *testing.T) {
+	first := testCommit(3, "one")
+	second := testCommit(4, "two")
+	second.Date = first.Date.Add(66 * time.Minute)
+	commits := []*git.Commit{first, second}
+
+	estimation := config.EstimationConfig{
+		Enabled:            true,
+		SessionGapMinutes:  120,
+		FirstCommitMinutes: 20,
+		Rounding:           config.RoundingQuarterHour,
+	}
+	estimates := effortEstimates(commits, estimation)
+	if got := estimates[first.Hash]; got != 0.5 {
+		t.Errorf("expected the first commit of the session rounded to 0.5h, got %v", got)
+	}
+	if got := estimates[second.Hash]; got != 1.25 {
+		t.Errorf("expected 1.1h rounded to 1.25h, got %v", got)
+	}
+	if got := totalEffort(commits, estimates); got != 1.75 {
+		t.Errorf("expected a total of the rounded estimates, got %v", got)
+	}
+}
+
+func TestEffortColumnShowsRoundedEstimates(t *testing.T) {
+	first := testCommit(3, "one")
+	second := testCommit(4, "two")
+	second.Date = first.Date.Add(66 * time.Minute)
+	data := testReportData(first, second)
+	data.Config.Estimation = config.EstimationConfig{
+		Enabled:            true,
+		SessionGapMinutes:  120,
+		FirstCommitMinutes: 20,
+		Rounding:           config.RoundingQuarterHour,
+	}
+
+	items := pdfText(t, renderPDF(t, data))
+	findText(t, items, "1.25")
+	findText(t, items, "0.50")
+}
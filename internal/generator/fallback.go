@@ -0,0 +1,242 @@
+package generator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"unicode"
+)
+
+// fallbackFace is a font used for glyphs missing in the primary font
+type fallbackFace struct {
+	family string
+	runes  map[rune]bool
+}
+
+// textRun is a part of a string rendered with a single font family
+type textRun struct {
+	text   string
+	family string
+}
+
+// loadFallbackFonts registers the configured fallback fonts and reads the
+// glyph coverage of them and of the primary font. Fallback faces have no
+// bold or italic variants, so their regular face is used for every style.
+func (g *PDFGenerator) loadFallbackFonts(primary []byte, paths []string) error {
+	g.primaryRunes, g.fallbacks = nil, nil
+	if len(paths) == 0 {
+		return nil
+	}
+
+	var err error
+	if g.primaryRunes, err = fontCoverage(primary); err != nil {
+		return fmt.Errorf("failed to read glyph coverage of %s: %w", fontFile, err)
+	}
+
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read fallback font: %w", err)
+		}
+		runes, err := fontCoverage(data)
+		if err != nil {
+			return fmt.Errorf("failed to read glyph coverage of fallback font %s: %w", path, err)
+		}
+
+		family := fmt.Sprintf("Fallback%d", i+1)
+		for _, style := range []string{"", "B", "I"} {
+			g.pdf.AddUTF8FontFromBytes(family, style, data)
+		}
+		if err := g.pdf.Error(); err != nil {
+			return fmt.Errorf("failed to load fallback font %s: %w", path, err)
+		}
+		g.fallbacks = append(g.fallbacks, fallbackFace{family: family, runes: runes})
+	}
+	return nil
+}
+
+// textRuns splits text into runs of the primary font and of the first
+// fallback font covering each glyph the primary font lacks. Glyphs no font
+// covers stay in the primary font.
+func (g *PDFGenerator) textRuns(text string) []textRun {
+	var runs []textRun
+	for _, r := range text {
+		family := fontName
+		if !g.primaryRunes[r] && !unicode.IsSpace(r) {
+			for _, face := range g.fallbacks {
+				if face.runes[r] {
+					family = face.family
+					break
+				}
+			}
+		}
+
+		if n := len(runs); n > 0 && runs[n-1].family == family {
+			runs[n-1].text += string(r)
+		} else {
+			runs = append(runs, textRun{text: string(r), family: family})
+		}
+	}
+	return runs
+}
+
+// cellText writes a single line of text into a cell of the given size at the
+// current position, switching to fallback fonts mid-line where the primary
+// font lacks glyphs. The position and primary font are left as CellFormat
+// with ln 0 would leave them.
+func (g *PDFGenerator) cellText(width, height float64, text, align, style string) {
+	runs := g.textRuns(text)
+	if len(g.fallbacks) == 0 || (len(runs) == 1 && runs[0].family == fontName) {
+		g.pdf.CellFormat(width, height, text, "", 0, align, false, 0, "")
+		return
+	}
+
+	fontSize, unitSize := g.pdf.GetFontSize()
+	var textWidth float64
+	for _, run := range runs {
+		g.pdf.SetFont(run.family, style, fontSize)
+		textWidth += g.pdf.GetStringWidth(run.text)
+	}
+
+	x, y := g.pdf.GetXY()
+	margin := g.pdf.GetCellMargin()
+	textX := x + margin
+	switch align {
+	case "C":
+		textX = x + (width-textWidth)/2
+	case "R":
+		textX = x + width - margin - textWidth
+	}
+
+	// Same baseline as CellFormat
+	baseline := y + height/2 + 0.3*unitSize
+	for _, run := range runs {
+		g.pdf.SetFont(run.family, style, fontSize)
+		g.pdf.Text(textX, baseline, run.text)
+		textX += g.pdf.GetStringWidth(run.text)
+	}
+
+	g.pdf.SetFont(fontName, style, fontSize)
+	g.pdf.SetXY(x+width, y)
+}
+
+// fontCoverage returns the characters a TrueType font maps to glyphs,
+// read from the Unicode subtables (formats 4 and 12) of its cmap table
+func fontCoverage(data []byte) (map[rune]bool, error) {
+	be := binary.BigEndian
+	if len(data) < 12 {
+		return nil, fmt.Errorf("not a TrueType font")
+	}
+
+	numTables := int(be.Uint16(data[4:]))
+	var cmap []byte
+	for i := 0; i < numTables; i++ {
+		record := 12 + 16*i
+		if record+16 > len(data) {
+			return nil, fmt.Errorf("truncated table directory")
+		}
+		if string(data[record:record+4]) == "cmap" {
+			offset, length := be.Uint32(data[record+8:]), be.Uint32(data[record+12:])
+			if uint64(offset)+uint64(length) > uint64(len(data)) {
+				return nil, fmt.Errorf("truncated cmap table")
+			}
+			cmap = data[offset : offset+length]
+			break
+		}
+	}
+	if len(cmap) < 4 {
+		return nil, fmt.Errorf("no cmap table")
+	}
+
+	runes := make(map[rune]bool)
+	subtables := int(be.Uint16(cmap[2:]))
+	for i := 0; i < subtables; i++ {
+		record := 4 + 8*i
+		if record+8 > len(cmap) {
+			return nil, fmt.Errorf("truncated cmap table")
+		}
+		platform, encoding := be.Uint16(cmap[record:]), be.Uint16(cmap[record+2:])
+		if platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+
+		offset := int(be.Uint32(cmap[record+4:]))
+		if offset+2 > len(cmap) {
+			return nil, fmt.Errorf("truncated cmap subtable")
+		}
+		var err error
+		switch be.Uint16(cmap[offset:]) {
+		case 4:
+			err = cmapFormat4(cmap[offset:], runes)
+		case 12:
+			err = cmapFormat12(cmap[offset:], runes)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return runes, nil
+}
+
+// cmapFormat4 adds the characters of a segment mapping subtable to runes
+func cmapFormat4(table []byte, runes map[rune]bool) error {
+	be := binary.BigEndian
+	if len(table) < 14 {
+		return fmt.Errorf("truncated cmap subtable")
+	}
+	segments := int(be.Uint16(table[6:])) / 2
+	ends := 14
+	starts := ends + 2*segments + 2
+	deltas := starts + 2*segments
+	rangeOffsets := deltas + 2*segments
+	if rangeOffsets+2*segments > len(table) {
+		return fmt.Errorf("truncated cmap subtable")
+	}
+
+	for s := 0; s < segments; s++ {
+		end := int(be.Uint16(table[ends+2*s:]))
+		start := int(be.Uint16(table[starts+2*s:]))
+		delta := int(be.Uint16(table[deltas+2*s:]))
+		rangeOffset := int(be.Uint16(table[rangeOffsets+2*s:]))
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			glyph := (c + delta) & 0xFFFF
+			if rangeOffset != 0 {
+				at := rangeOffsets + 2*s + rangeOffset + 2*(c-start)
+				if at+2 > len(table) {
+					continue
+				}
+				glyph = int(be.Uint16(table[at:]))
+				if glyph != 0 {
+					glyph = (glyph + delta) & 0xFFFF
+				}
+			}
+			if glyph != 0 {
+				runes[rune(c)] = true
+			}
+		}
+	}
+	return nil
+}
+
+// cmapFormat12 adds the characters of a segmented coverage subtable to runes
+func cmapFormat12(table []byte, runes map[rune]bool) error {
+	be := binary.BigEndian
+	if len(table) < 16 {
+		return fmt.Errorf("truncated cmap subtable")
+	}
+	groups := int(be.Uint32(table[12:]))
+	if 16+12*groups > len(table) {
+		return fmt.Errorf("truncated cmap subtable")
+	}
+
+	for i := 0; i < groups; i++ {
+		group := table[16+12*i:]
+		start, end, glyph := be.Uint32(group), be.Uint32(group[4:]), be.Uint32(group[8:])
+		for c := start; c <= end && c <= unicode.MaxRune; c++ {
+			if glyph+(c-start) != 0 {
+				runes[rune(c)] = true
+			}
+		}
+	}
+	return nil
+}
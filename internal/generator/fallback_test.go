@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"git-report-generator/fonts"
+)
+
+func TestFontCoverage(t *testing.T) {
+	data, err := fonts.FS.ReadFile(fontFile)
+	if err != nil {
+		t.Fatalf("failed to read font: %v", err)
+	}
+	runes, err := fontCoverage(data)
+	if err != nil {
+		t.Fatalf("failed to read coverage: %v", err)
+	}
+	for _, r := range "aZż€" {
+		if !runes[r] {
+			t.Errorf("expected %q to be covered by %s", r, fontFile)
+		}
+	}
+	if runes['中'] {
+		t.Errorf("expected no CJK glyphs in %s", fontFile)
+	}
+
+	if _, err := fontCoverage([]byte("not a font")); err == nil {
+		t.Error("expected an error for data that is not a font")
+	}
+}
+
+func TestTextRunsSwitchToFallback(t *testing.T) {
+	g := NewPDFGenerator()
+	g.primaryRunes = map[rune]bool{'f': true, 'i': true, 'x': true}
+	g.fallbacks = []fallbackFace{
+		{family: "Fallback1", runes: map[rune]bool{'😀': true}},
+		{family: "Fallback2", runes: map[rune]bool{'中': true, '文': true}},
+	}
+
+	want := []textRun{
+		{text: "fix ", family: fontName},
+		{text: "中文", family: "Fallback2"},
+		{text: " ", family: fontName},
+		{text: "😀", family: "Fallback1"},
+		{text: "ア", family: fontName},
+	}
+	if got := g.textRuns("fix 中文 😀ア"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestMissingFallbackFont(t *testing.T) {
+	data := testReportData(testCommit(3, "中文"))
+	data.Config.PDF.FallbackFonts = []string{filepath.Join(t.TempDir(), "missing.ttf")}
+
+	if err := NewPDFGenerator().Write(data, io.Discard); err == nil {
+		t.Error("expected an error for a missing fallback font")
+	}
+}
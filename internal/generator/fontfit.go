@@ -0,0 +1,30 @@
+package generator
+
+// Bounds of the table font shrinking done by font_size_auto
+const (
+	minFontScale  = 0.6
+	fontScaleStep = 0.05
+)
+
+// renderFitted renders the report, and with font_size_auto re-renders it
+// with an ever smaller commit table font until it fits the page cap. A
+// report that still overflows at the minimum scale is kept at that scale.
+func (g *PDFGenerator) renderFitted(data *ReportData) error {
+	g.fontScale = 0
+	if err := g.render(data); err != nil {
+		return err
+	}
+
+	maxPages := data.Config.PDF.MaxPages
+	if !data.Config.PDF.FontSizeAuto || maxPages <= 0 {
+		return nil
+	}
+
+	for scale := 1 - fontScaleStep; g.pdf.PageNo() > maxPages && scale >= minFontScale-1e-9; scale -= fontScaleStep {
+		g.fontScale = scale
+		if err := g.render(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
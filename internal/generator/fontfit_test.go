@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"git-report-generator/internal/git"
+)
+
+// manyCommits returns n commits spread over January 2024
+func manyCommits(n int) []*git.Commit {
+	commits := make([]*git.Commit, n)
+	for i := range commits {
+		commits[i] = testCommit(i%28+1, fmt.Sprintf("change %d", i))
+	}
+	return commits
+}
+
+func TestFontSizeAutoShrinksToPageCap(t *testing.T) {
+	data := testReportData(manyCommits(60)...)
+	content := renderPDF(t, data)
+	if pages := len(pdfPageStreams(t, content)); pages != 3 {
+		t.Fatalf("expected the commits to overflow onto 3 pages at the default size, got %d", pages)
+	}
+	defaultSize := findText(t, pdfText(t, content), "change 0").size
+
+	data.Config.PDF.MaxPages = 2
+	data.Config.PDF.FontSizeAuto = true
+	content = renderPDF(t, data)
+	if pages := len(pdfPageStreams(t, content)); pages != 2 {
+		t.Errorf("expected the report shrunk to 2 pages, got %d", pages)
+	}
+	if size := findText(t, pdfText(t, content), "change 0").size; size >= defaultSize || size < defaultSize*minFontScale-1e-6 {
+		t.Errorf("expected the table font shrunk from %.2f within bounds, got %.2f", defaultSize, size)
+	}
+}
+
+func TestFontSizeAutoStopsAtMinimumScale(t *testing.T) {
+	data := testReportData(manyCommits(200)...)
+	data.Config.PDF.MaxPages = 1
+	data.Config.PDF.FontSizeAuto = true
+	content := renderPDF(t, data)
+
+	defaultData := testReportData(manyCommits(1)...)
+	defaultSize := findText(t, pdfText(t, renderPDF(t, defaultData)), "change 0").size
+	size := findText(t, pdfText(t, content), "change 0").size
+	if want := defaultSize * minFontScale; size < want-0.01 || size > want+0.01 {
+		t.Errorf("expected the table font kept at the minimum scale %.2f, got %.2f", want, size)
+	}
+	if pages := len(pdfPageStreams(t, content)); pages <= 1 {
+		t.Errorf("expected the report to still overflow the cap, got %d pages", pages)
+	}
+}
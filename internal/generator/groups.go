@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+// authorKey identifies the author of a commit, preferring the email
+func authorKey(commit *git.Commit) string {
+	if commit.AuthorEmail != "" {
+		return strings.ToLower(commit.AuthorEmail)
+	}
+	return strings.ToLower(commit.Author)
+}
+
+// SplitByAuthor partitions the commits by author, authors with more commits
+// first. The order of commits within an author is kept.
+func SplitByAuthor(commits []*git.Commit) [][]*git.Commit {
+	var keys []string
+	byAuthor := make(map[string][]*git.Commit)
+	for _, commit := range commits {
+		key := authorKey(commit)
+		if _, ok := byAuthor[key]; !ok {
+			keys = append(keys, key)
+		}
+		byAuthor[key] = append(byAuthor[key], commit)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return len(byAuthor[keys[i]]) > len(byAuthor[keys[j]])
+	})
+
+	parts := make([][]*git.Commit, len(keys))
+	for i, key := range keys {
+		parts[i] = byAuthor[key]
+	}
+	return parts
+}
+
+// groupKey returns the key of the report section the commit belongs to
+func (d *ReportData) groupKey(commit *git.Commit) string {
+	switch d.Config.PDF.GroupBy {
+	case config.GroupByDay:
+		return d.displayTime(commit.Date).Format("2006-01-02")
+	case config.GroupByRelease:
+		// Zero-padded so that the keys sort chronologically
+		return fmt.Sprintf("%06d", d.releaseIndex(commit))
+	case config.GroupByBranch:
+		// Keep the branches in the order they were given
+		for i, branch := range d.Branches {
+			if branch == commit.Branch {
+				return fmt.Sprintf("%06d", i)
+			}
+		}
+		return fmt.Sprintf("%06d", len(d.Branches))
+	}
+	return authorKey(commit)
+}
+
+// releaseIndex returns the index of the first release tagged at or after the
+// commit date, or len(d.Releases) for commits after the last release
+func (d *ReportData) releaseIndex(commit *git.Commit) int {
+	return sort.Search(len(d.Releases), func(i int) bool {
+		return !d.Releases[i].Date.Before(commit.Date)
+	})
+}
+
+// releaseLabel names the release section of the commit, e.g. "v1.1.0 → v1.2.0"
+func (d *ReportData) releaseLabel(commit *git.Commit) string {
+	i := d.releaseIndex(commit)
+	from, to := "początek", "HEAD"
+	if i > 0 {
+		from = d.Releases[i-1].Name
+	}
+	if i < len(d.Releases) {
+		to = d.Releases[i].Name
+	}
+	return from + " → " + to
+}
+
+// GroupCommits reorders the report commits so that the commits of each
+// section are consecutive. Author sections are ordered by commit count (most
+// first unless sort.groups is "asc"), day and release sections follow the
+// commit order unless sort.groups is set. Commits within a section keep their order unless
+// sort.within_group is set.
+func GroupCommits(data *ReportData) []*git.Commit {
+	if data.Config.PDF.GroupBy == "" {
+		return data.Commits
+	}
+
+	var keys []string
+	groups := make(map[string][]*git.Commit)
+	for _, commit := range data.Commits {
+		key := data.groupKey(commit)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], commit)
+	}
+
+	order := data.Config.Sort
+	if data.Config.PDF.GroupBy == config.GroupByAuthor {
+		sort.SliceStable(keys, func(i, j int) bool {
+			a, b := len(groups[keys[i]]), len(groups[keys[j]])
+			if order.Groups == config.SortAscending {
+				return a < b
+			}
+			return a > b
+		})
+	} else if order.Groups != "" {
+		// Day and release keys are formatted so that they sort chronologically
+		sort.SliceStable(keys, func(i, j int) bool {
+			if order.Groups == config.SortAscending {
+				return keys[i] < keys[j]
+			}
+			return keys[i] > keys[j]
+		})
+	}
+
+	grouped := make([]*git.Commit, 0, len(data.Commits))
+	for _, key := range keys {
+		commits := groups[key]
+		if order.WithinGroup != "" {
+			sort.SliceStable(commits, func(i, j int) bool {
+				if order.WithinGroup == config.SortAscending {
+					return commits[i].Date.Before(commits[j].Date)
+				}
+				return commits[i].Date.After(commits[j].Date)
+			})
+		}
+		grouped = append(grouped, commits...)
+	}
+
+	return grouped
+}
+
+// groupRuns splits the report commits into runs of consecutive commits of
+// the same section, as produced by GroupCommits
+func (d *ReportData) groupRuns() [][]*git.Commit {
+	var runs [][]*git.Commit
+	for i, commit := range d.Commits {
+		if i == 0 || d.groupKey(commit) != d.groupKey(d.Commits[i-1]) {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], commit)
+	}
+	return runs
+}
+
+// emptyDaysBetween returns the days of the report range without commits,
+// split by the day section they precede: entry i holds the empty days before
+// section i and the last entry those after the last section. The commits are
+// expected to be grouped by day.
+func (d *ReportData) emptyDaysBetween(sections int) [][]time.Time {
+	gaps := make([][]time.Time, sections+1)
+	next := 0
+	var last time.Time
+	for _, row := range calendarRows(d.Commits, d.DateFrom, d.DateTo, d.displayTime) {
+		if row.commit == nil {
+			gaps[next] = append(gaps[next], row.day)
+			continue
+		}
+		if next == 0 || !row.day.Equal(last) {
+			next++
+			last = row.day
+		}
+	}
+	return gaps
+}
+
+// DropSparseAuthors removes the commits of authors with fewer than
+// minCommits commits. It returns the remaining commits, in their original
+// order, and the number of authors omitted.
+func DropSparseAuthors(commits []*git.Commit, minCommits int) ([]*git.Commit, int) {
+	counts := make(map[string]int)
+	for _, commit := range commits {
+		counts[authorKey(commit)]++
+	}
+
+	omitted := 0
+	for _, count := range counts {
+		if count < minCommits {
+			omitted++
+		}
+	}
+
+	kept := make([]*git.Commit, 0, len(commits))
+	for _, commit := range commits {
+		if counts[authorKey(commit)] >= minCommits {
+			kept = append(kept, commit)
+		}
+	}
+
+	return kept, omitted
+}
@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+// annaCommit returns a commit by a second author
+func annaCommit(day int, subject string) *git.Commit {
+	commit := testCommit(day, subject)
+	commit.Author = "Anna Nowak"
+	commit.AuthorEmail = "anna@example.com"
+	return commit
+}
+
+func TestGroupByAuthorRendersTwoSections(t *testing.T) {
+	data := testReportData(annaCommit(5, "Anna second"), testCommit(4, "Jan only"), annaCommit(3, "Anna first"))
+	data.Config.PDF.GroupBy = config.GroupByAuthor
+	data.Commits = GroupCommits(data)
+
+	if got, want := subjectsOf(data.Commits), []string{"Anna second", "Anna first", "Jan only"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("grouped commits = %q, want %q", got, want)
+	}
+
+	items := pdfText(t, renderPDF(t, data))
+	var sections []string
+	for _, item := range items {
+		if strings.HasPrefix(item.text, "Autor: ") || strings.HasPrefix(item.text, "Liczba commitów: ") {
+			sections = append(sections, item.text)
+		}
+	}
+	want := []string{
+		"Autor: Anna Nowak <anna@example.com>",
+		"Liczba commitów: 2",
+		"Autor: Jan Kowalski <jan@example.com>",
+		"Liczba commitów: 1",
+	}
+	if !reflect.DeepEqual(sections[:min(len(sections), len(want))], want) {
+		t.Errorf("sections = %q, want %q", sections, want)
+	}
+}
+
+// subjectsOf returns the commit subjects in order
+func subjectsOf(commits []*git.Commit) []string {
+	subjects := make([]string, len(commits))
+	for i, commit := range commits {
+		subjects[i] = commit.Message
+	}
+	return subjects
+}
+
+func TestDayGroupsDescendingWithinGroupAscending(t *testing.T) {
+	at := func(day, hour int, subject string) *git.Commit {
+		commit := testCommit(day, subject)
+		commit.Date = commit.Date.Add(time.Duration(hour-10) * time.Hour)
+		return commit
+	}
+	data := testReportData(at(3, 10, "3rd morning"), at(5, 18, "5th evening"), at(3, 15, "3rd afternoon"), at(5, 9, "5th morning"))
+	data.Config.PDF.GroupBy = config.GroupByDay
+	data.Config.Sort.Groups = config.SortDescending
+	data.Config.Sort.WithinGroup = config.SortAscending
+
+	got := subjectsOf(GroupCommits(data))
+	want := []string{"5th morning", "5th evening", "3rd morning", "3rd afternoon"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("grouped commits = %q, want %q", got, want)
+	}
+}
+
+func TestDaySectionsListEachEmptyDayOnce(t *testing.T) {
+	data := testReportData(testCommit(3, "First change"), testCommit(5, "Second change"))
+	data.DateFrom = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	data.DateTo = time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	data.Config.PDF.GroupBy = config.GroupByDay
+	data.Config.PDF.IncludeEmptyDays = true
+	data.Commits = GroupCommits(data)
+
+	var days []string
+	for _, item := range pdfText(t, renderPDF(t, data)) {
+		if strings.HasPrefix(item.text, "Dzień: ") {
+			days = append(days, item.text)
+		}
+	}
+	want := []string{
+		"Dzień: 2024-01-02 – brak commitów",
+		"Dzień: 2024-01-03",
+		"Dzień: 2024-01-04 – brak commitów",
+		"Dzień: 2024-01-05",
+		"Dzień: 2024-01-06 – brak commitów",
+	}
+	if !reflect.DeepEqual(days, want) {
+		t.Errorf("day sections = %q, want %q", days, want)
+	}
+}
+
+func TestDropSparseAuthorsOmitsSingleCommitAuthor(t *testing.T) {
+	commits := []*git.Commit{testCommit(5, "Jan second"), annaCommit(4, "Anna only"), testCommit(3, "Jan first")}
+
+	kept, omitted := DropSparseAuthors(commits, 2)
+	if got, want := subjectsOf(kept), []string{"Jan second", "Jan first"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("kept = %q, want %q", got, want)
+	}
+	if omitted != 1 {
+		t.Errorf("omitted = %d, want 1", omitted)
+	}
+
+	data := testReportData(kept...)
+	data.Config.PDF.GroupBy = config.GroupByAuthor
+	data.OmittedAuthors = omitted
+	data.MinCommits = 2
+	if text := pdfJoinedText(t, renderPDF(t, data)); !strings.Contains(text, "Pominięci autorzy (mniej niż 2 commitów): 1") {
+		t.Errorf("omitted authors missing from the summary:\n%s", text)
+	}
+}
+
+func TestReleaseSectionsBetweenTags(t *testing.T) {
+	data := testReportData(testCommit(8, "after"), testCommit(6, "second"), testCommit(4, "first"), testCommit(2, "initial"))
+	data.Config.PDF.GroupBy = config.GroupByRelease
+	data.Releases = []git.Tag{
+		{Name: "v1.1.0", Date: testCommit(2, "").Date},
+		{Name: "v1.2.0", Date: testCommit(6, "").Date},
+	}
+	data.Commits = GroupCommits(data)
+
+	items := pdfText(t, renderPDF(t, data))
+	var sections []string
+	for _, item := range items {
+		if strings.HasPrefix(item.text, "Wydanie: ") {
+			sections = append(sections, item.text)
+		}
+	}
+	// Sections follow the newest-first commit order, the tagged commit
+	// closes its release
+	want := []string{
+		"Wydanie: v1.2.0 → HEAD",
+		"Wydanie: v1.1.0 → v1.2.0",
+		"Wydanie: początek → v1.1.0",
+	}
+	if !reflect.DeepEqual(sections, want) {
+		t.Errorf("sections = %q, want %q", sections, want)
+	}
+
+	for subject, label := range map[string]string{
+		"initial": "początek → v1.1.0",
+		"first":   "v1.1.0 → v1.2.0",
+		"second":  "v1.1.0 → v1.2.0",
+		"after":   "v1.2.0 → HEAD",
+	} {
+		for _, commit := range data.Commits {
+			if commit.Message == subject && data.releaseLabel(commit) != label {
+				t.Errorf("commit %q in release %q, want %q", subject, data.releaseLabel(commit), label)
+			}
+		}
+	}
+}
+
+func TestBranchSections(t *testing.T) {
+	onBranch := func(day int, subject, branch string) *git.Commit {
+		commit := testCommit(day, subject)
+		commit.Branch = branch
+		return commit
+	}
+	sections := func(groups string) []string {
+		data := testReportData(onBranch(5, "Release fix", "release/1.0"), onBranch(4, "Master feature", "master"), onBranch(2, "Shared change", "master"))
+		data.Branches = []string{"master", "release/1.0"}
+		data.Config.PDF.GroupBy = config.GroupByBranch
+		data.Config.Sort.Groups = groups
+		data.Commits = GroupCommits(data)
+
+		var headings []string
+		for _, item := range pdfText(t, renderPDF(t, data)) {
+			if strings.HasPrefix(item.text, "Gałąź: ") {
+				headings = append(headings, item.text)
+			}
+		}
+		return headings
+	}
+
+	// Sections follow the commit order, or the order the branches were given in
+	if got, want := sections(""), []string{"Gałąź: release/1.0", "Gałąź: master"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sections = %q, want %q", got, want)
+	}
+	if got, want := sections(config.SortAscending), []string{"Gałąź: master", "Gałąź: release/1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending sections = %q, want %q", got, want)
+	}
+}
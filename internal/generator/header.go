@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// headerText holds the rendered parts of the header template
+type headerText struct {
+	DateLine string // First template line, e.g. "Kraków, 2024-01-01 - 2024-01-31"
+	Title    string // Second template line, e.g. "Protokół odbioru prac programistycznych"
+	Body     string // Remaining lines
+}
+
+// headerTemplateData returns the placeholder values available in the header template
+func headerTemplateData(data *ReportData) map[string]interface{} {
+	preparedBy := data.Config.Header.PreparedBy
+	if preparedBy == "" {
+		preparedBy = data.Config.Header.ExecutorName
+	}
+	return map[string]interface{}{
+		"executor_name":   data.Config.Header.ExecutorName,
+		"prepared_by":     preparedBy,
+		"executor_email":  data.displayEmail(data.Config.Header.ExecutorEmail),
+		"recipient_name":  data.Config.Header.RecipientName,
+		"repository_name": data.RepositoryName,
+		"repository_path": data.RepositoryPath,
+		"branch_name":     data.BranchName,
+		"head_hash":       data.HeadHash,
+		"date_from":       data.formatReportDate(data.DateFrom),
+		"date_to":         data.formatReportDate(data.DateTo),
+		"date_range":      data.periodText(),
+	}
+}
+
+// periodText returns the report period as "from - to", or as a single date
+// for single-day reports
+func (d *ReportData) periodText() string {
+	from := d.formatReportDate(d.DateFrom)
+	to := d.formatReportDate(d.DateTo)
+	if from == to {
+		return from
+	}
+	return from + " - " + to
+}
+
+// renderHeader renders the configured header template into its date line,
+// title and body parts
+func renderHeader(data *ReportData) (*headerText, error) {
+	// Parse template to extract components
+	lines := strings.Split(data.Config.Header.Template, "\n")
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("invalid template format: not enough lines")
+	}
+
+	templateData := headerTemplateData(data)
+
+	dateText, err := renderTemplate("date", lines[0], templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collapse "2024-01-15 - 2024-01-15" of single-day reports to one date
+	from, to := templateData["date_from"].(string), templateData["date_to"].(string)
+	if from == to {
+		dateText = strings.Replace(dateText, from+" - "+to, from, 1)
+	}
+
+	// Rest of the template (excluding first two lines)
+	restText, err := renderTemplate("rest", strings.Join(lines[2:], "\n"), templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headerText{
+		DateLine: dateText,
+		Title:    lines[1], // Title doesn't need rendering, no variables
+		Body:     restText,
+	}, nil
+}
+
+// renderTemplate executes a single header template fragment
+func renderTemplate(name, text string, templateData map[string]interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", fmt.Errorf("failed to execute %s template: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepositoryNameOverrideInHeader(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	data.RepositoryName = "Acme Portal"
+	text := pdfJoinedText(t, renderPDF(t, data))
+
+	if !strings.Contains(text, "Repozytorium: Acme Portal") {
+		t.Errorf("header does not show the overridden repository name:\n%s", text)
+	}
+}
+
+func TestHeadHashInHeaderAndSummary(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	data.HeadHash = "0123456789abcdef0123456789abcdef01234567"
+	data.Config.Header.Template = "{{.date_from}}\nProtokół\nStan: {{.head_hash}}"
+	text := pdfJoinedText(t, renderPDF(t, data))
+
+	if !strings.Contains(text, "Stan: "+data.HeadHash) {
+		t.Errorf("header does not render the head_hash placeholder:\n%s", text)
+	}
+	if !strings.Contains(text, "Stan repozytorium: "+data.HeadHash) {
+		t.Errorf("summary does not show the HEAD hash:\n%s", text)
+	}
+}
+
+func TestSingleDayHeaderShowsOneDate(t *testing.T) {
+	data := testReportData(testCommit(15, "Add login form"))
+	data.DateFrom = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	data.DateTo = data.DateFrom
+
+	header, err := renderHeader(data)
+	if err != nil {
+		t.Fatalf("renderHeader failed: %v", err)
+	}
+	if header.DateLine != "Some City, 2024-01-15" {
+		t.Errorf("date line = %q, want a single date", header.DateLine)
+	}
+	if got := data.periodText(); got != "2024-01-15" {
+		t.Errorf("period = %q, want 2024-01-15", got)
+	}
+
+	data.DateTo = time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	if header, _ := renderHeader(data); header.DateLine != "Some City, 2024-01-15 - 2024-01-16" {
+		t.Errorf("two-day date line = %q, want the range", header.DateLine)
+	}
+}
+
+func TestPreparedByLine(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	data.Config.Header.ExecutorName = "Ewa Zielińska"
+
+	header, err := renderHeader(data)
+	if err != nil {
+		t.Fatalf("renderHeader failed: %v", err)
+	}
+	if !strings.Contains(header.Body, "Sporządził: Ewa Zielińska") {
+		t.Errorf("prepared by does not default to the executor:\n%s", header.Body)
+	}
+
+	data.Config.Header.PreparedBy = "Anna Nowak"
+	text := pdfJoinedText(t, renderPDF(t, data))
+	if !strings.Contains(text, "Sporządził: Anna Nowak") {
+		t.Errorf("header does not show the prepared by line:\n%s", text)
+	}
+	if !strings.Contains(text, "Ewa Zielińska") {
+		t.Errorf("header no longer shows the executor:\n%s", text)
+	}
+}
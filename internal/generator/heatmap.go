@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// heatmapMaxWeeks is the number of week columns drawn per band; longer
+	// ranges continue in another band below
+	heatmapMaxWeeks = 27
+
+	// heatmapLabelWidth is the width reserved for the weekday labels
+	heatmapLabelWidth = 10.0
+
+	// heatmapGap is the space between neighbouring day cells
+	heatmapGap = 0.8
+)
+
+// heatmapColors are the cell colors for no commits and the four activity levels
+var heatmapColors = [5][3]int{
+	{235, 237, 240},
+	{155, 233, 168},
+	{64, 196, 99},
+	{48, 161, 78},
+	{33, 110, 57},
+}
+
+// heatmapCell is a single day of the activity heatmap
+type heatmapCell struct {
+	day     time.Time
+	week    int // Column, counted from the week containing the first day
+	weekday int // Row, 0 for Monday
+	count   int
+}
+
+// heatmapCells returns one cell per day of the report range with the number
+// of commits made on it
+func heatmapCells(data *ReportData) []heatmapCell {
+	counts := make(map[time.Time]int)
+	for _, commit := range data.Commits {
+		counts[calendarDay(data.displayTime(commit.Date))]++
+	}
+
+	first := calendarDay(data.DateFrom)
+	firstWeekday := (int(first.Weekday()) + 6) % 7
+	var cells []heatmapCell
+	for day, i := first, 0; !day.After(calendarDay(data.DateTo)); day, i = day.AddDate(0, 0, 1), i+1 {
+		cells = append(cells, heatmapCell{
+			day:     day,
+			week:    (firstWeekday + i) / 7,
+			weekday: (firstWeekday + i) % 7,
+			count:   counts[day],
+		})
+	}
+	return cells
+}
+
+// heatmapLevel maps a day's commit count to one of the heatmap colors,
+// relative to the busiest day of the range
+func heatmapLevel(count, maxCount int) int {
+	if count == 0 || maxCount == 0 {
+		return 0
+	}
+	return int(math.Ceil(4 * float64(count) / float64(maxCount)))
+}
+
+// generateHeatmap renders a page with a contribution heatmap of the report
+// range, weeks as columns and weekdays as rows
+func (g *PDFGenerator) generateHeatmap(data *ReportData) {
+	sizes := data.Config.PDF.FontSizes
+	cells := heatmapCells(data)
+	if len(cells) == 0 {
+		return
+	}
+
+	names := lookupLocale(data.Config.Lang)
+	maxCount := 0
+	for _, cell := range cells {
+		if cell.count > maxCount {
+			maxCount = cell.count
+		}
+	}
+
+	g.pdf.AddPage()
+	g.pdf.SetFont(fontName, "B", sizes.Title)
+	g.pdf.CellFormat(0, 10, "Mapa aktywności", "", 1, "L", false, 0, "")
+	g.pdf.Ln(2)
+
+	// Short ranges keep the size of a full band instead of stretching
+	weeks := cells[len(cells)-1].week + 1
+	bandWeeks := weeks
+	if bandWeeks > heatmapMaxWeeks {
+		bandWeeks = heatmapMaxWeeks
+	}
+	cellSize := (g.availableWidth()-heatmapLabelWidth)/heatmapMaxWeeks - heatmapGap
+	step := cellSize + heatmapGap
+	left := g.leftMargin()
+
+	for band := 0; band*heatmapMaxWeeks < weeks; band++ {
+		top := g.pdf.GetY() + 4
+
+		g.pdf.SetFont(fontName, "", sizes.Footer)
+		g.pdf.SetTextColor(120, 120, 120)
+		// Weeks start on Monday
+		for row := 0; row < 7; row++ {
+			label := capitalize(names.weekdaysShort[(row+1)%7])
+			g.pdf.SetXY(left, top+float64(row)*step)
+			g.pdf.CellFormat(heatmapLabelWidth, cellSize, label, "", 0, "L", false, 0, "")
+		}
+
+		// Label the first column and every month start, unless too close to the previous label
+		labelled := -1
+		for _, cell := range cells {
+			column := cell.week - band*heatmapMaxWeeks
+			if column < 0 || column >= bandWeeks {
+				continue
+			}
+			x := left + heatmapLabelWidth + float64(column)*step
+			if (labelled < 0 || cell.day.Day() == 1) && (labelled < 0 || column >= labelled+3) {
+				g.pdf.SetXY(x, top-4)
+				g.pdf.CellFormat(3*step, 4, names.monthsShort[cell.day.Month()-1], "", 0, "L", false, 0, "")
+				labelled = column
+			}
+			color := heatmapColors[heatmapLevel(cell.count, maxCount)]
+			g.pdf.SetFillColor(color[0], color[1], color[2])
+			g.pdf.Rect(x, top+float64(cell.weekday)*step, cellSize, cellSize, "F")
+		}
+
+		g.pdf.SetY(top + 7*step + 4)
+	}
+
+	// Legend
+	g.pdf.SetX(left + heatmapLabelWidth)
+	g.pdf.CellFormat(12, cellSize, "Mniej", "", 0, "L", false, 0, "")
+	for _, color := range heatmapColors {
+		g.pdf.SetFillColor(color[0], color[1], color[2])
+		g.pdf.Rect(g.pdf.GetX(), g.pdf.GetY(), cellSize, cellSize, "F")
+		g.pdf.SetX(g.pdf.GetX() + step)
+	}
+	g.pdf.CellFormat(12, cellSize, " Więcej", "", 1, "L", false, 0, "")
+	g.pdf.SetTextColor(0, 0, 0)
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeatmapCellsCoverRange(t *testing.T) {
+	data := testReportData(
+		testCommit(3, "one"),
+		testCommit(3, "two"),
+		testCommit(10, "three"),
+	)
+
+	cells := heatmapCells(data)
+	if len(cells) != 31 {
+		t.Fatalf("expected 31 cells for January, got %d", len(cells))
+	}
+	// January 1st 2024 is a Monday
+	if first := cells[0]; first.week != 0 || first.weekday != 0 {
+		t.Errorf("expected the first day in week 0 on Monday, got week %d weekday %d", first.week, first.weekday)
+	}
+	if last := cells[30]; last.week != 4 || last.weekday != 2 {
+		t.Errorf("expected January 31st in week 4 on Wednesday, got week %d weekday %d", last.week, last.weekday)
+	}
+
+	total := 0
+	for _, cell := range cells {
+		total += cell.count
+	}
+	if total != 3 {
+		t.Errorf("expected 3 commits across the cells, got %d", total)
+	}
+	if cells[2].count != 2 || cells[9].count != 1 {
+		t.Errorf("expected 2 commits on the 3rd and 1 on the 10th, got %d and %d", cells[2].count, cells[9].count)
+	}
+}
+
+func TestHeatmapCellsShortRange(t *testing.T) {
+	data := testReportData()
+	data.DateFrom = time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	data.DateTo = time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	cells := heatmapCells(data)
+	if len(cells) != 3 {
+		t.Fatalf("expected 3 cells, got %d", len(cells))
+	}
+	// Saturday, Sunday, then Monday in the next column
+	if cells[0].weekday != 5 || cells[2].week != 1 || cells[2].weekday != 0 {
+		t.Errorf("unexpected cell positions: %+v", cells)
+	}
+}
+
+func TestHeatmapLevel(t *testing.T) {
+	tests := []struct {
+		count, max, want int
+	}{
+		{0, 0, 0},
+		{0, 8, 0},
+		{1, 8, 1},
+		{2, 8, 1},
+		{3, 8, 2},
+		{8, 8, 4},
+	}
+	for _, tt := range tests {
+		if got := heatmapLevel(tt.count, tt.max); got != tt.want {
+			t.Errorf("heatmapLevel(%d, %d) = %d, want %d", tt.count, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestHeatmapPage(t *testing.T) {
+	data := testReportData(testCommit(3, "one"))
+	data.Config.PDF.ShowHeatmap = true
+
+	if !strings.Contains(pdfJoinedText(t, renderPDF(t, data)), "Mapa aktywności") {
+		t.Error("expected the heatmap page in the report")
+	}
+
+	data.Config.PDF.ShowHeatmap = false
+	if strings.Contains(pdfJoinedText(t, renderPDF(t, data)), "Mapa aktywności") {
+		t.Error("expected no heatmap page when disabled")
+	}
+}
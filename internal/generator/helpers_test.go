@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+// testCommit returns a commit with the given subject authored at 10:00 UTC
+// on the given day of January 2024
+func testCommit(day int, subject string) *git.Commit {
+	hash := strings.Repeat(strconv.Itoa(day%10), 40)
+	return &git.Commit{
+		SHA:         hash[:8],
+		Hash:        hash,
+		Date:        time.Date(2024, 1, day, 10, 0, 0, 0, time.UTC),
+		Message:     subject,
+		Author:      "Jan Kowalski",
+		AuthorEmail: "jan@example.com",
+	}
+}
+
+// testReportData returns report data with the default configuration for the
+// commits, spanning January 2024
+func testReportData(commits ...*git.Commit) *ReportData {
+	return &ReportData{
+		Config:         config.DefaultConfig(),
+		RepositoryName: "repo",
+		BranchName:     "main",
+		Branches:       []string{"main"},
+		HeadHash:       strings.Repeat("f", 40),
+		AuthorEmail:    "jan@example.com",
+		AuthorEmails:   []string{"jan@example.com"},
+		DateFrom:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		DateTo:         time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Commits:        commits,
+	}
+}
+
+// renderPDF renders the report with the PDF generator into memory
+func renderPDF(t *testing.T, data *ReportData) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := NewPDFGenerator().Write(data, &buf); err != nil {
+		t.Fatalf("failed to render PDF: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// pdfTextItem is a string drawn in a PDF with the font resource, font size
+// and position, in points from the bottom left corner of the page, it was
+// drawn at
+type pdfTextItem struct {
+	text string
+	font string
+	size float64
+	x, y float64
+}
+
+var (
+	pdfStreamPattern = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+	pdfTextOpPattern = regexp.MustCompile(`/(\S+) ([\d.]+) Tf|([\d.-]+) ([\d.-]+) Td|\(((?:\\.|[^\\)])*)\) ?Tj`)
+)
+
+// pdfPageStreams returns the inflated page content streams of the PDF
+func pdfPageStreams(t *testing.T, content []byte) [][]byte {
+	t.Helper()
+	var streams [][]byte
+	for _, match := range pdfStreamPattern.FindAllSubmatch(content, -1) {
+		reader, err := zlib.NewReader(bytes.NewReader(match[1]))
+		if err != nil {
+			continue
+		}
+		stream, err := io.ReadAll(reader)
+		if err != nil || !bytes.Contains(stream, []byte("BT ")) {
+			// Fonts and images
+			continue
+		}
+		streams = append(streams, stream)
+	}
+	return streams
+}
+
+// pdfText returns the strings drawn in the page content streams of the PDF,
+// in drawing order
+func pdfText(t *testing.T, content []byte) []pdfTextItem {
+	t.Helper()
+	var items []pdfTextItem
+	for _, stream := range pdfPageStreams(t, content) {
+		var font string
+		var size, x, y float64
+		for _, op := range pdfTextOpPattern.FindAllSubmatch(stream, -1) {
+			switch {
+			case op[1] != nil:
+				font = string(op[1])
+				size, _ = strconv.ParseFloat(string(op[2]), 64)
+			case op[3] != nil:
+				x, _ = strconv.ParseFloat(string(op[3]), 64)
+				y, _ = strconv.ParseFloat(string(op[4]), 64)
+			default:
+				items = append(items, pdfTextItem{text: decodePDFString(op[5]), font: font, size: size, x: x, y: y})
+			}
+		}
+	}
+	return items
+}
+
+// decodePDFString decodes an escaped literal string holding UTF-16BE text,
+// as gofpdf writes them for UTF-8 fonts
+func decodePDFString(literal []byte) string {
+	var raw []byte
+	for i := 0; i < len(literal); i++ {
+		c := literal[i]
+		if c != '\\' || i+1 == len(literal) {
+			raw = append(raw, c)
+			continue
+		}
+		i++
+		switch literal[i] {
+		case 'n':
+			raw = append(raw, '\n')
+		case 'r':
+			raw = append(raw, '\r')
+		case 't':
+			raw = append(raw, '\t')
+		default:
+			if literal[i] >= '0' && literal[i] <= '7' {
+				end := i
+				for end < len(literal) && end < i+3 && literal[end] >= '0' && literal[end] <= '7' {
+					end++
+				}
+				value, _ := strconv.ParseUint(string(literal[i:end]), 8, 8)
+				raw = append(raw, byte(value))
+				i = end - 1
+			} else {
+				raw = append(raw, literal[i])
+			}
+		}
+	}
+
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = uint16(raw[2*i])<<8 | uint16(raw[2*i+1])
+	}
+	return string(utf16.Decode(units))
+}
+
+// pdfJoinedText returns all strings drawn in the PDF separated by newlines
+func pdfJoinedText(t *testing.T, content []byte) string {
+	t.Helper()
+	var lines []string
+	for _, item := range pdfText(t, content) {
+		lines = append(lines, item.text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findText returns the first drawn string containing text
+func findText(t *testing.T, items []pdfTextItem, text string) pdfTextItem {
+	t.Helper()
+	for _, item := range items {
+		if strings.Contains(item.text, text) {
+			return item
+		}
+	}
+	t.Fatalf("text %q not found in PDF", text)
+	return pdfTextItem{}
+}
+
+// rowText returns the first string drawn on the same line as y that starts
+// with prefix, e.g. the date cell of a table row
+func rowText(items []pdfTextItem, y float64, prefix string) string {
+	for _, item := range items {
+		if item.y == y && strings.HasPrefix(item.text, prefix) {
+			return item.text
+		}
+	}
+	return ""
+}
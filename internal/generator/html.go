@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"time"
+)
+
+// defaultHTMLTemplate is used when no template file is configured
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html lang="pl">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #323232; }
+h1 { text-align: center; font-size: 1.4em; }
+pre.header { font-family: inherit; white-space: pre-wrap; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+.sha { font-family: monospace; }
+.description { color: #666; }
+.note { color: #666; font-style: italic; }
+pre.disclaimer { font-family: inherit; font-size: 0.8em; color: #787878; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<p>{{.DateLine}}</p>
+<h1>{{.Title}}</h1>
+<pre class="header">{{.HeaderText}}</pre>
+{{if .Commits}}
+<table>
+<tr><th>Data</th><th>SHA</th><th>Opis</th></tr>
+{{range .Commits}}<tr>
+<td>{{date .Date}}</td>
+<td class="sha">{{.SHA}}</td>
+<td>{{.Message}}{{if .Description}}<div class="description">{{.Description}}</div>{{end}}{{if .Note}}<div class="note">Notatka: {{.Note}}</div>{{end}}</td>
+</tr>
+{{end}}</table>
+{{else}}
+<p><em>Brak commitów w wybranym okresie.</em></p>
+{{end}}
+{{if .Config.PDF.ShowSummary}}
+<p><strong>Łączna liczba commitów: {{len .Commits}}</strong></p>
+{{if .AuthorEmail}}<p>Autor: {{if .AuthorName}}{{.AuthorName}} &lt;{{email .AuthorEmail}}&gt;{{else}}{{email .AuthorEmail}}{{end}}</p>{{end}}
+{{end}}
+{{if .Disclaimer}}<pre class="disclaimer">{{.Disclaimer}}</pre>{{end}}
+</body>
+</html>
+`
+
+// htmlView is the data passed to HTML templates: the report data plus the
+// rendered header parts
+type htmlView struct {
+	*ReportData
+	DateLine   string
+	Title      string
+	HeaderText string
+	Disclaimer string
+}
+
+// HTMLGenerator renders reports as a single HTML page
+type HTMLGenerator struct{}
+
+func init() {
+	Register(NewHTMLGenerator())
+}
+
+// NewHTMLGenerator creates a new HTML generator
+func NewHTMLGenerator() *HTMLGenerator {
+	return &HTMLGenerator{}
+}
+
+// Format returns the output format name of the generator
+func (g *HTMLGenerator) Format() string {
+	return "html"
+}
+
+// Write renders the report as HTML to the given writer. The template is
+// parsed and executed before anything is written, so a broken template
+// never produces a partial report.
+func (g *HTMLGenerator) Write(data *ReportData, out io.Writer) error {
+	tmpl, err := g.loadTemplate(data)
+	if err != nil {
+		return err
+	}
+
+	header, err := renderHeader(data)
+	if err != nil {
+		return err
+	}
+
+	disclaimer, err := renderTemplate("disclaimer", data.Config.Footer.Disclaimer, headerTemplateData(data))
+	if err != nil {
+		return err
+	}
+
+	view := htmlView{
+		ReportData: data,
+		DateLine:   header.DateLine,
+		Title:      header.Title,
+		HeaderText: header.Body,
+		Disclaimer: disclaimer,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, view); err != nil {
+		return fmt.Errorf("failed to execute HTML template: %w", err)
+	}
+
+	if _, err := buf.WriteTo(out); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+
+	return nil
+}
+
+// loadTemplate parses the configured template file, or the built-in
+// template when none is set
+func (g *HTMLGenerator) loadTemplate(data *ReportData) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"date": func(t time.Time) string {
+			return data.displayTime(t).Format("2006-01-02")
+		},
+		"email": data.displayEmail,
+	}
+
+	name, text := "report", defaultHTMLTemplate
+	if path := data.Config.HTML.TemplateFile; path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HTML template: %w", err)
+		}
+		name, text = path, string(content)
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML template %s: %w", name, err)
+	}
+
+	return tmpl, nil
+}
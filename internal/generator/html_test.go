@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHTMLTemplateFileControlsMarkup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html.tmpl")
+	custom := `<ul class="custom">{{range .Commits}}<li data-sha="{{.SHA}}">{{date .Date}} {{.Message}}</li>{{end}}</ul>`
+	if err := os.WriteFile(path, []byte(custom), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	data := testReportData(testCommit(3, "Add <login> form"))
+	data.Config.HTML.TemplateFile = path
+	var buf bytes.Buffer
+	if err := NewHTMLGenerator().Write(data, &buf); err != nil {
+		t.Fatalf("failed to render HTML: %v", err)
+	}
+
+	want := `<ul class="custom"><li data-sha="33333333">2024-01-03 Add &lt;login&gt; form</li></ul>`
+	if got := buf.String(); got != want {
+		t.Errorf("HTML = %q, want %q", got, want)
+	}
+}
+
+func TestHTMLDefaultTemplateAndBrokenTemplate(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	var buf bytes.Buffer
+	if err := NewHTMLGenerator().Write(data, &buf); err != nil {
+		t.Fatalf("failed to render HTML: %v", err)
+	}
+	if html := buf.String(); !strings.HasPrefix(html, "<!DOCTYPE html>") || !strings.Contains(html, "<td>Add login form") {
+		t.Errorf("built-in template not used:\n%s", html)
+	}
+
+	path := filepath.Join(t.TempDir(), "broken.tmpl")
+	if err := os.WriteFile(path, []byte("{{range .Commits}}"), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	data.Config.HTML.TemplateFile = path
+	buf.Reset()
+	if err := NewHTMLGenerator().Write(data, &buf); err == nil {
+		t.Error("expected an error for a template that does not parse")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("broken template wrote a partial report: %q", buf.String())
+	}
+}
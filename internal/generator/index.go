@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IndexEntry describes one generated report file listed in an index page
+type IndexEntry struct {
+	Path    string // Path of the report file
+	Label   string // Author or part the file covers
+	Commits int    // Number of commits in the file
+}
+
+// indexTemplate renders the landing page linking the generated reports
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="pl">
+<head>
+<meta charset="utf-8">
+<title>Raporty</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #323232; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0f0f0; }
+</style>
+</head>
+<body>
+<h1>Raporty</h1>
+<table>
+<tr><th>Plik</th><th>Zakres</th><th>Liczba commitów</th></tr>
+{{range .}}<tr><td><a href="{{.Link}}">{{.Link}}</a></td><td>{{.Label}}</td><td>{{.Commits}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// WriteIndex writes an HTML page linking to every generated report file.
+// Links are relative to the directory of the index file.
+func WriteIndex(indexPath string, entries []IndexEntry) error {
+	type row struct {
+		IndexEntry
+		Link string
+	}
+
+	rows := make([]row, len(entries))
+	indexDir := filepath.Dir(indexPath)
+	for i, entry := range entries {
+		link, err := filepath.Rel(indexDir, entry.Path)
+		if err != nil {
+			link = entry.Path
+		}
+		rows[i] = row{IndexEntry: entry, Link: filepath.ToSlash(link)}
+	}
+
+	var buf bytes.Buffer
+	if err := indexTemplate.Execute(&buf, rows); err != nil {
+		return fmt.Errorf("failed to render index: %w", err)
+	}
+
+	if err := os.WriteFile(indexPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+
+	return nil
+}
+
+// unsafeFileChars matches characters replaced when deriving file names
+var unsafeFileChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// AuthorPath returns the file path for the report of a single author,
+// e.g. "report.pdf" becomes "report-jan.kowalski-example.com.pdf"
+func AuthorPath(outputPath, author string) string {
+	slug := strings.Trim(unsafeFileChars.ReplaceAllString(strings.ToLower(author), "-"), "-")
+	if slug == "" {
+		slug = "unknown"
+	}
+	ext := filepath.Ext(outputPath)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(outputPath, ext), slug, ext)
+}
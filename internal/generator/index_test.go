@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteIndexListsEveryFile(t *testing.T) {
+	dir := t.TempDir()
+	entries := []IndexEntry{
+		{Path: filepath.Join(dir, "report-jan-example.com.pdf"), Label: "Jan Kowalski", Commits: 12},
+		{Path: filepath.Join(dir, "report-anna-example.com.pdf"), Label: "Anna Nowak", Commits: 3},
+	}
+	indexPath := filepath.Join(dir, "index.html")
+	if err := WriteIndex(indexPath, entries); err != nil {
+		t.Fatalf("WriteIndex failed: %v", err)
+	}
+
+	content, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	html := string(content)
+	for _, entry := range entries {
+		name := filepath.Base(entry.Path)
+		row := `<tr><td><a href="` + name + `">` + name + `</a></td><td>` + entry.Label + `</td><td>` + strconv.Itoa(entry.Commits) + `</td></tr>`
+		if !strings.Contains(html, row) {
+			t.Errorf("index does not list %s as %s with %d commits:\n%s", name, entry.Label, entry.Commits, html)
+		}
+	}
+	if got := strings.Count(html, "<a href="); got != len(entries) {
+		t.Errorf("index has %d links, want %d", got, len(entries))
+	}
+}
+
+func TestAuthorPath(t *testing.T) {
+	if got := AuthorPath("out/report.pdf", "Jan.Kowalski@Example.com"); got != "out/report-jan.kowalski-example.com.pdf" {
+		t.Errorf("AuthorPath = %q", got)
+	}
+	if got := AuthorPath("report.pdf", "@@"); got != "report-unknown.pdf" {
+		t.Errorf("AuthorPath without usable characters = %q", got)
+	}
+}
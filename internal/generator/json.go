@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+// commitRecord is the serialized form of a commit in the JSON based formats
+type commitRecord struct {
+	SHA          string   `json:"sha"`
+	Hash         string   `json:"hash"`
+	Date         string   `json:"date"`
+	Author       string   `json:"author"`
+	AuthorEmail  string   `json:"author_email"`
+	Subject      string   `json:"subject"`
+	Description  string   `json:"description,omitempty"`
+	Ticket       string   `json:"ticket,omitempty"`
+	Note         string   `json:"note,omitempty"`
+	SignOffs     []string `json:"sign_offs,omitempty"`
+	PullRequest  int      `json:"pull_request,omitempty"`
+	Branch       string   `json:"branch,omitempty"`
+	Signed       bool     `json:"signed"`
+	Insertions   *int     `json:"insertions,omitempty"`
+	Deletions    *int     `json:"deletions,omitempty"`
+	NetLines     *int     `json:"net_lines,omitempty"`
+	BytesAdded   *int64   `json:"bytes_added,omitempty"`
+	BytesRemoved *int64   `json:"bytes_removed,omitempty"`
+	Diff         string   `json:"diff,omitempty"`
+}
+
+// newCommitRecord serializes a commit, including the statistics the report
+// was configured to collect
+func newCommitRecord(data *ReportData, commit *git.Commit) commitRecord {
+	record := commitRecord{
+		SHA:         commit.SHA,
+		Hash:        commit.Hash,
+		Date:        data.displayTime(commit.Date).Format(time.RFC3339),
+		Author:      commit.Author,
+		AuthorEmail: data.displayEmail(commit.AuthorEmail),
+		Subject:     commit.Message,
+		Description: commit.Description,
+		Ticket:      commit.Ticket,
+		Note:        commit.Note,
+		SignOffs:    commit.SignOffs,
+		PullRequest: commit.PullRequest,
+		Signed:      commit.Signature != "",
+		Diff:        commit.Diff,
+	}
+	if data.Config.Stats.WithStats {
+		record.Insertions = &commit.Insertions
+		record.Deletions = &commit.Deletions
+		record.NetLines = &commit.NetLines
+	}
+	if len(data.Branches) > 1 {
+		record.Branch = commit.Branch
+	}
+	if data.Config.Stats.ByteStats {
+		record.BytesAdded = &commit.BytesAdded
+		record.BytesRemoved = &commit.BytesRemoved
+	}
+	return record
+}
+
+// JSONGenerator writes the report commits as a JSON array
+type JSONGenerator struct{}
+
+// NDJSONGenerator writes the report commits as newline-delimited JSON, one
+// object per line, for streaming consumers
+type NDJSONGenerator struct{}
+
+func init() {
+	Register(&JSONGenerator{})
+	Register(&NDJSONGenerator{})
+}
+
+// Format returns the output format name of the generator
+func (g *JSONGenerator) Format() string {
+	return "json"
+}
+
+// Write renders the commits as a JSON array, indented when PrettyJSON is set
+func (g *JSONGenerator) Write(data *ReportData, out io.Writer) error {
+	records := make([]commitRecord, len(data.Commits))
+	for i, commit := range data.Commits {
+		records[i] = newCommitRecord(data, commit)
+	}
+
+	encoder := json.NewEncoder(out)
+	if data.PrettyJSON {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
+
+// Format returns the output format name of the generator
+func (g *NDJSONGenerator) Format() string {
+	return "ndjson"
+}
+
+// Write renders every commit as a single-line JSON object
+func (g *NDJSONGenerator) Write(data *ReportData, out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	for _, commit := range data.Commits {
+		if err := encoder.Encode(newCommitRecord(data, commit)); err != nil {
+			return fmt.Errorf("failed to write NDJSON record: %w", err)
+		}
+	}
+	return nil
+}
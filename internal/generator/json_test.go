@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNDJSONLinesParseAsCommits(t *testing.T) {
+	first := testCommit(3, "Add login form")
+	first.Description = "Line one\nLine two"
+	data := testReportData(testCommit(5, "Fix \"quoted\" bug"), first)
+
+	var buf bytes.Buffer
+	if err := (&NDJSONGenerator{}).Write(data, &buf); err != nil {
+		t.Fatalf("failed to write NDJSON: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(data.Commits) {
+		t.Fatalf("got %d lines, want one per commit:\n%s", len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var record commitRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line %d does not parse on its own: %v", i+1, err)
+		}
+		commit := data.Commits[i]
+		if record.Hash != commit.Hash || record.Subject != commit.Message || record.Description != commit.Description {
+			t.Errorf("line %d = %+v, want commit %s %q", i+1, record, commit.SHA, commit.Message)
+		}
+	}
+}
+
+func TestPrettyAndCompactJSONAreEquivalent(t *testing.T) {
+	data := testReportData(testCommit(5, "Fix bug"), testCommit(3, "Add login form"))
+
+	render := func(pretty bool) []byte {
+		data.PrettyJSON = pretty
+		var buf bytes.Buffer
+		if err := (&JSONGenerator{}).Write(data, &buf); err != nil {
+			t.Fatalf("failed to write JSON: %v", err)
+		}
+		return buf.Bytes()
+	}
+	compact, pretty := render(false), render(true)
+
+	if bytes.Count(bytes.TrimSpace(compact), []byte("\n")) != 0 {
+		t.Errorf("compact JSON spans several lines:\n%s", compact)
+	}
+	if !bytes.Contains(pretty, []byte("\n  {\n    \"sha\": ")) {
+		t.Errorf("pretty JSON is not indented:\n%s", pretty)
+	}
+
+	var fromCompact, fromPretty []commitRecord
+	if err := json.Unmarshal(compact, &fromCompact); err != nil {
+		t.Fatalf("compact JSON is invalid: %v", err)
+	}
+	if err := json.Unmarshal(pretty, &fromPretty); err != nil {
+		t.Fatalf("pretty JSON is invalid: %v", err)
+	}
+	if !reflect.DeepEqual(fromCompact, fromPretty) || len(fromCompact) != 2 {
+		t.Errorf("compact %+v and pretty %+v JSON differ", fromCompact, fromPretty)
+	}
+}
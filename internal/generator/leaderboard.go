@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"sort"
+
+	"git-report-generator/internal/git"
+)
+
+// medalColors are the gold, silver and bronze fills of the top three places
+var medalColors = [3][3]int{
+	{212, 175, 55},
+	{192, 192, 192},
+	{205, 127, 50},
+}
+
+// leaderboardEntry holds the totals of a single author
+type leaderboardEntry struct {
+	author   string
+	email    string
+	commits  int
+	netLines int
+}
+
+// leaderboard totals the commits per author, ordered by commit count and
+// then by net lines, with ties broken by name
+func leaderboard(commits []*git.Commit) []leaderboardEntry {
+	var entries []leaderboardEntry
+	for _, authorCommits := range SplitByAuthor(commits) {
+		entry := leaderboardEntry{
+			author:  authorCommits[0].Author,
+			email:   authorCommits[0].AuthorEmail,
+			commits: len(authorCommits),
+		}
+		for _, commit := range authorCommits {
+			entry.netLines += commit.NetLines
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].commits != entries[j].commits {
+			return entries[i].commits > entries[j].commits
+		}
+		if entries[i].netLines != entries[j].netLines {
+			return entries[i].netLines > entries[j].netLines
+		}
+		return entries[i].author < entries[j].author
+	})
+	return entries
+}
+
+// generateLeaderboard renders a page ranking the authors by commits and,
+// with line statistics, by net lines. Single-author reports get no page.
+func (g *PDFGenerator) generateLeaderboard(data *ReportData) {
+	entries := leaderboard(data.Commits)
+	if len(entries) < 2 {
+		return
+	}
+
+	g.pdf.AddPage()
+	g.pdf.SetFont(fontName, "B", data.Config.PDF.FontSizes.Title)
+	g.pdf.CellFormat(0, 10, "Ranking autorów", "", 1, "L", false, 0, "")
+	g.pdf.Ln(2)
+
+	g.drawLeaderboard(data, "Liczba commitów", entries, func(e leaderboardEntry) string {
+		return data.formatNumber(e.commits)
+	})
+
+	if data.Config.Stats.WithStats {
+		byLines := append([]leaderboardEntry(nil), entries...)
+		sort.SliceStable(byLines, func(i, j int) bool {
+			return byLines[i].netLines > byLines[j].netLines
+		})
+		g.pdf.Ln(8)
+		g.drawLeaderboard(data, "Zmiana netto linii", byLines, func(e leaderboardEntry) string {
+			return data.formatSigned(e.netLines)
+		})
+	}
+}
+
+// drawLeaderboard renders one ranking table, marking the top three places
+// with medal colored badges
+func (g *PDFGenerator) drawLeaderboard(data *ReportData, heading string, entries []leaderboardEntry, value func(leaderboardEntry) string) {
+	const (
+		rankWidth  = 18.0
+		valueWidth = 35.0
+		rowHeight  = 8.0
+	)
+	size := data.Config.PDF.FontSizes.Table
+	authorWidth := g.availableWidth() - rankWidth - valueWidth
+
+	g.pdf.SetFont(fontName, "B", size)
+	g.pdf.SetFillColor(220, 220, 220)
+	g.pdf.CellFormat(rankWidth, rowHeight, "Miejsce", "1", 0, "C", true, 0, "")
+	g.pdf.CellFormat(authorWidth, rowHeight, "Autor", "1", 0, "C", true, 0, "")
+	g.pdf.CellFormat(valueWidth, rowHeight, heading, "1", 1, "C", true, 0, "")
+
+	for i, entry := range entries {
+		x, y := g.pdf.GetXY()
+		g.pdf.SetFont(fontName, "", size)
+		if i < len(medalColors) {
+			color := medalColors[i]
+			g.pdf.SetFillColor(color[0], color[1], color[2])
+			g.pdf.Circle(x+rankWidth/2, y+rowHeight/2, rowHeight/2-1, "F")
+			g.pdf.SetFont(fontName, "B", size)
+		}
+		g.pdf.CellFormat(rankWidth, rowHeight, data.formatNumber(i+1), "1", 0, "C", false, 0, "")
+
+		g.pdf.SetFont(fontName, "", size)
+		label := g.fitText(AuthorLabel(entry.author, data.displayEmail(entry.email)), authorWidth)
+		g.pdf.CellFormat(authorWidth, rowHeight, label, "1", 0, "L", false, 0, data.mailtoLink(entry.email))
+		g.pdf.CellFormat(valueWidth, rowHeight, value(entry), "1", 1, "R", false, 0, "")
+	}
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/git"
+)
+
+// piotrCommit returns a commit by a third author
+func piotrCommit(day int, subject string) *git.Commit {
+	commit := testCommit(day, subject)
+	commit.Author = "Piotr Zieliński"
+	commit.AuthorEmail = "piotr@example.com"
+	return commit
+}
+
+func TestLeaderboardRanking(t *testing.T) {
+	commits := []*git.Commit{
+		annaCommit(2, "a1"),
+		testCommit(3, "j1"), testCommit(4, "j2"), testCommit(5, "j3"),
+		piotrCommit(6, "p1"), annaCommit(7, "a2"),
+	}
+
+	var authors []string
+	var counts []int
+	for _, entry := range leaderboard(commits) {
+		authors = append(authors, entry.author)
+		counts = append(counts, entry.commits)
+	}
+	if want := []string{"Jan Kowalski", "Anna Nowak", "Piotr Zieliński"}; !reflect.DeepEqual(authors, want) {
+		t.Errorf("ranking = %q, want %q", authors, want)
+	}
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("commit counts = %v, want %v", counts, want)
+	}
+}
+
+func TestLeaderboardTiesByNetLines(t *testing.T) {
+	anna, jan, piotr := annaCommit(2, "a"), testCommit(3, "j"), piotrCommit(4, "p")
+	anna.NetLines, jan.NetLines, piotr.NetLines = 5, 40, 5
+
+	var authors []string
+	for _, entry := range leaderboard([]*git.Commit{anna, jan, piotr}) {
+		authors = append(authors, entry.author)
+	}
+	// Equal net lines fall back to the name
+	if want := []string{"Jan Kowalski", "Anna Nowak", "Piotr Zieliński"}; !reflect.DeepEqual(authors, want) {
+		t.Errorf("ranking = %q, want %q", authors, want)
+	}
+}
+
+func TestLeaderboardPage(t *testing.T) {
+	data := testReportData(annaCommit(2, "a1"), testCommit(3, "j1"), testCommit(4, "j2"))
+	if strings.Contains(pdfJoinedText(t, renderPDF(t, data)), "Ranking autorów") {
+		t.Fatal("expected no leaderboard page unless enabled")
+	}
+
+	data.Config.PDF.ShowLeaderboard = true
+	items := pdfText(t, renderPDF(t, data))
+	start := -1
+	for i, item := range items {
+		if item.text == "Ranking autorów" {
+			start = i
+		}
+	}
+	if start < 0 {
+		t.Fatal("expected a leaderboard page")
+	}
+
+	// Rows are drawn top to bottom
+	var ranked []string
+	for _, item := range items[start:] {
+		if strings.HasSuffix(item.text, "@example.com>") {
+			ranked = append(ranked, item.text)
+		}
+	}
+	want := []string{"Jan Kowalski <jan@example.com>", "Anna Nowak <anna@example.com>"}
+	if !reflect.DeepEqual(ranked, want) {
+		t.Errorf("leaderboard rows = %q, want %q", ranked, want)
+	}
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// locale holds the month and weekday names of a report language
+type locale struct {
+	months        [12]string
+	monthsOfDay   [12]string // Form following a day number, e.g. "15 stycznia"
+	monthsShort   [12]string
+	weekdays      [7]string // Indexed by time.Weekday, Sunday first
+	weekdaysShort [7]string
+
+	// Number separators used with localized numbers
+	thousandsSeparator string
+	decimalSeparator   string
+}
+
+// locales are the supported report languages by code
+var locales = map[string]locale{
+	"pl": {
+		months:        [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+		monthsOfDay:   [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		monthsShort:   [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+		weekdays:      [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		weekdaysShort: [7]string{"nd", "pn", "wt", "śr", "cz", "pt", "sb"},
+
+		thousandsSeparator: "\u00a0", // No-break space keeps the groups together
+		decimalSeparator:   ",",
+	},
+	"en": {
+		months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsOfDay:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsShort:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		weekdaysShort: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+
+		thousandsSeparator: ",",
+		decimalSeparator:   ".",
+	},
+}
+
+// nameTokens are the time layout elements replaced by localized names,
+// longest first so "January" is not taken for "Jan"
+var nameTokens = []string{"January", "Monday", "Jan", "Mon"}
+
+// lookupLocale returns the locale of the language, Polish when unknown
+func lookupLocale(lang string) locale {
+	if l, ok := locales[lang]; ok {
+		return l
+	}
+	return locales["pl"]
+}
+
+// formatDate formats t like time.Format, with month and weekday names in the
+// given language
+func formatDate(t time.Time, layout, lang string) string {
+	l := lookupLocale(lang)
+
+	var sb strings.Builder
+	for layout != "" {
+		// Find the earliest name element, formatting the text before it as is
+		at, token := -1, ""
+		for _, candidate := range nameTokens {
+			if i := strings.Index(layout, candidate); i >= 0 && (at < 0 || i < at) {
+				at, token = i, candidate
+			}
+		}
+		if at < 0 {
+			sb.WriteString(t.Format(layout))
+			break
+		}
+
+		sb.WriteString(t.Format(layout[:at]))
+		switch token {
+		case "January":
+			// Day layouts ("2", "02", "_2") end in 2, and take the month in its inflected form
+			if strings.HasSuffix(strings.TrimRight(layout[:at], " ."), "2") {
+				sb.WriteString(l.monthsOfDay[t.Month()-1])
+			} else {
+				sb.WriteString(l.months[t.Month()-1])
+			}
+		case "Jan":
+			sb.WriteString(l.monthsShort[t.Month()-1])
+		case "Monday":
+			sb.WriteString(l.weekdays[t.Weekday()])
+		case "Mon":
+			sb.WriteString(l.weekdaysShort[t.Weekday()])
+		}
+		layout = layout[at+len(token):]
+	}
+	return sb.String()
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// formatReportDate formats a report date with the configured date format
+// and language
+func (d *ReportData) formatReportDate(t time.Time) string {
+	return formatDate(d.displayTime(t), d.Config.Header.DateFormat, d.Config.Lang)
+}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatDateMonthNames(t *testing.T) {
+	date := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		layout, lang, want string
+	}{
+		{"January 2006", "pl", "styczeń 2024"},
+		{"2 January 2006", "pl", "15 stycznia 2024"},
+		{"02 Jan 2006", "pl", "15 sty 2024"},
+		{"Monday, 2006-01-02", "pl", "poniedziałek, 2024-01-15"},
+		{"Mon 2.01.", "pl", "pn 15.01."},
+		{"January 2, 2006", "en", "January 15, 2024"},
+		{"Mon Jan 2", "en", "Mon Jan 15"},
+		{"2006-01-02", "pl", "2024-01-15"},
+		{"January 2006", "xx", "styczeń 2024"},
+	}
+	for _, tt := range tests {
+		if got := formatDate(date, tt.layout, tt.lang); got != tt.want {
+			t.Errorf("formatDate(%q, %q) = %q, want %q", tt.layout, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestHeaderDatesUsePolishMonthNames(t *testing.T) {
+	data := testReportData(testCommit(3, "one"))
+	data.Config.Header.DateFormat = "2 January 2006"
+
+	text := pdfJoinedText(t, renderPDF(t, data))
+	if !strings.Contains(text, "1 stycznia 2024 - 31 stycznia 2024") {
+		t.Errorf("expected Polish month names in the header, got %q", text)
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// defaultLogoHeight is the logo height in mm when no size is configured
+const defaultLogoHeight = 15
+
+// logoSize returns the rendered logo size in mm for an image of the given
+// pixel size, fitting it within the configured maximum width and height
+// while keeping its aspect ratio
+func logoSize(pixelWidth, pixelHeight int, maxWidth, maxHeight float64) (width, height float64) {
+	if maxWidth == 0 && maxHeight == 0 {
+		maxHeight = defaultLogoHeight
+	}
+	ratio := float64(pixelWidth) / float64(pixelHeight)
+
+	width, height = maxWidth, maxWidth/ratio
+	if maxWidth == 0 || (maxHeight > 0 && height > maxHeight) {
+		width, height = maxHeight*ratio, maxHeight
+	}
+	return width, height
+}
+
+// drawLogo renders the configured logo at the top of the current page, at
+// the configured alignment, and moves the cursor below it
+func (g *PDFGenerator) drawLogo(data *ReportData) error {
+	header := data.Config.Header
+	file, err := os.Open(header.Logo)
+	if err != nil {
+		return fmt.Errorf("failed to open logo: %w", err)
+	}
+	defer file.Close()
+
+	imgConfig, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return fmt.Errorf("failed to decode logo %s: %w", header.Logo, err)
+	}
+	if imgConfig.Width == 0 || imgConfig.Height == 0 {
+		return fmt.Errorf("logo %s is empty", header.Logo)
+	}
+
+	width, height := logoSize(imgConfig.Width, imgConfig.Height, header.LogoWidth, header.LogoHeight)
+	x := g.leftMargin()
+	switch header.LogoAlign {
+	case "C":
+		x += (g.availableWidth() - width) / 2
+	case "R":
+		x += g.availableWidth() - width
+	}
+
+	y := g.pdf.GetY()
+	g.pdf.ImageOptions(header.Logo, x, y, width, height, false, gofpdf.ImageOptions{ReadDpi: false}, 0, "")
+	if err := g.pdf.Error(); err != nil {
+		return fmt.Errorf("failed to embed logo %s: %w", header.Logo, err)
+	}
+	g.pdf.SetY(y + height + 3)
+	return nil
+}
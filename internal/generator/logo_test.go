@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"image/color"
+	"io"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pdfImagePattern matches an image placement in a page content stream
+var pdfImagePattern = regexp.MustCompile(`q ([\d.]+) 0 0 ([\d.]+) ([\d.]+) ([\d.]+) cm /I\S+ Do Q`)
+
+func TestLogoSize(t *testing.T) {
+	tests := []struct {
+		name                  string
+		maxWidth, maxHeight   float64
+		wantWidth, wantHeight float64
+	}{
+		{"default height", 0, 0, 30, 15},
+		{"width only", 40, 0, 40, 20},
+		{"height only", 0, 10, 20, 10},
+		{"limited by height", 40, 10, 20, 10},
+		{"limited by width", 20, 30, 20, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := logoSize(200, 100, tt.maxWidth, tt.maxHeight)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("expected %vx%v, got %vx%v", tt.wantWidth, tt.wantHeight, width, height)
+			}
+		})
+	}
+}
+
+// logoPosition renders the report and returns the logo's left and right
+// edges in mm
+func logoPosition(t *testing.T, data *ReportData) (left, right float64) {
+	t.Helper()
+	streams := pdfPageStreams(t, renderPDF(t, data))
+	match := pdfImagePattern.FindSubmatch(streams[0])
+	if match == nil {
+		t.Fatal("expected the logo on the first page")
+	}
+	width, _ := strconv.ParseFloat(string(match[1]), 64)
+	x, _ := strconv.ParseFloat(string(match[3]), 64)
+	const mmPerPoint = 25.4 / 72
+	return x * mmPerPoint, (x + width) * mmPerPoint
+}
+
+func TestLogoAlignment(t *testing.T) {
+	logo := filepath.Join(t.TempDir(), "logo.png")
+	writePNG(t, logo, 200, 100, color.Black)
+
+	const pageWidth = 210
+	tests := []struct {
+		align     string
+		wantLeft  float64
+		wantRight float64
+	}{
+		{"L", 20, 40},
+		{"C", 95, 115},
+		{"R", pageWidth - 40, pageWidth - 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.align, func(t *testing.T) {
+			data := testReportData(testCommit(2, "Add feature"))
+			data.Config.Header.Logo = logo
+			data.Config.Header.LogoAlign = tt.align
+			data.Config.Header.LogoWidth = 20
+
+			left, right := logoPosition(t, data)
+			if math.Abs(left-tt.wantLeft) > 0.1 || math.Abs(right-tt.wantRight) > 0.1 {
+				t.Errorf("expected the logo at %v-%vmm, got %.2f-%.2fmm", tt.wantLeft, tt.wantRight, left, right)
+			}
+		})
+	}
+}
+
+func TestMissingLogo(t *testing.T) {
+	data := testReportData(testCommit(2, "Add feature"))
+	data.Config.Header.Logo = filepath.Join(t.TempDir(), "missing.png")
+
+	err := NewPDFGenerator().Write(data, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "logo") {
+		t.Errorf("expected a logo error, got %v", err)
+	}
+}
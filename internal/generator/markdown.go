@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MarkdownGenerator renders reports as Markdown with a GitHub-flavored pipe
+// table, e.g. to embed them in wikis and pull requests
+type MarkdownGenerator struct{}
+
+func init() {
+	Register(NewMarkdownGenerator())
+}
+
+// NewMarkdownGenerator creates a new Markdown generator
+func NewMarkdownGenerator() *MarkdownGenerator {
+	return &MarkdownGenerator{}
+}
+
+// Format returns the output format name of the generator
+func (g *MarkdownGenerator) Format() string {
+	return "md"
+}
+
+// Generate writes the Markdown report to the given output path
+func (g *MarkdownGenerator) Generate(data *ReportData, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create Markdown file: %w", err)
+	}
+
+	if err := g.Write(data, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to save Markdown report: %w", err)
+	}
+	return nil
+}
+
+// Write renders the report as Markdown to the given writer
+func (g *MarkdownGenerator) Write(data *ReportData, out io.Writer) error {
+	header, err := renderHeader(data)
+	if err != nil {
+		return err
+	}
+	disclaimer, err := renderTemplate("disclaimer", data.Config.Footer.Disclaimer, headerTemplateData(data))
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", header.DateLine)
+	fmt.Fprintf(&b, "# %s\n\n", header.Title)
+	// Keep the line breaks of the header template, with blank lines
+	// separating paragraphs
+	for _, paragraph := range strings.Split(strings.TrimSpace(header.Body), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			fmt.Fprintf(&b, "%s\n\n", strings.ReplaceAll(paragraph, "\n", "  \n"))
+		}
+	}
+
+	if len(data.Commits) == 0 {
+		b.WriteString("_Brak commitów w podanym okresie._\n\n")
+	} else {
+		g.commitTable(&b, data)
+	}
+
+	if data.Config.PDF.ShowSummary {
+		b.WriteString("## Podsumowanie\n\n")
+		fmt.Fprintf(&b, "- Łączna liczba commitów: **%s**\n", data.formatNumber(len(data.Commits)))
+		if len(data.AuthorEmails) > 1 {
+			b.WriteString("- Autorzy:\n")
+			for _, count := range data.authorCounts() {
+				fmt.Fprintf(&b, "  - %s: %s\n", count.label, data.formatNumber(count.commits))
+			}
+		} else if author := data.summaryAuthor(); author != "" {
+			fmt.Fprintf(&b, "- Autor: %s\n", author)
+		}
+		fmt.Fprintf(&b, "- Okres: %s\n\n", data.periodText())
+	}
+
+	if disclaimer != "" {
+		fmt.Fprintf(&b, "_%s_\n\n", strings.ReplaceAll(strings.TrimSpace(disclaimer), "\n", "_  \n_"))
+	}
+	fmt.Fprintf(&b, "Suma kontrolna commitów: `%s`\n", CommitSetDigest(data.Commits))
+
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown report: %w", err)
+	}
+	return nil
+}
+
+// commitTable renders the commits as a pipe table with the same columns as
+// the PDF table
+func (g *MarkdownGenerator) commitTable(b *strings.Builder, data *ReportData) {
+	withTickets := data.Config.CommitParsing.ShowTicketColumn
+	withStats := data.Config.Stats.WithStats
+
+	headings := []string{"Data", "SHA"}
+	aligns := []string{":---:", ":---:"}
+	if withTickets {
+		headings = append(headings, "Zgłoszenie")
+		aligns = append(aligns, ":---:")
+	}
+	headings = append(headings, "Opis")
+	aligns = append(aligns, "---")
+	if withStats {
+		headings = append(headings, data.linesHeading())
+		aligns = append(aligns, "---:")
+	}
+	fmt.Fprintf(b, "| %s |\n| %s |\n", strings.Join(headings, " | "), strings.Join(aligns, " | "))
+
+	for _, commit := range data.Commits {
+		cells := []string{data.displayTime(commit.Date).Format("2006-01-02"), "`" + commit.SHA + "`"}
+		if withTickets {
+			cells = append(cells, markdownCell(commit.Ticket))
+		}
+
+		description := "**" + markdownCell(commit.Message) + "**"
+		if commit.Description != "" {
+			description += "<br>" + markdownCell(commit.Description)
+		}
+		if commit.Note != "" {
+			description += "<br>_Notatka: " + markdownCell(commit.Note) + "_"
+		}
+		cells = append(cells, description)
+
+		if withStats {
+			cells = append(cells, data.linesText(commit))
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+	}
+	b.WriteString("\n")
+}
+
+// markdownCell escapes text for a single table cell: pipes would end the
+// cell and line breaks the row
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
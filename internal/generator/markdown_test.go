@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// renderMarkdown renders the report as Markdown
+func renderMarkdown(t *testing.T, data *ReportData) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := NewMarkdownGenerator().Write(data, &buf); err != nil {
+		t.Fatalf("failed to render Markdown: %v", err)
+	}
+	return buf.String()
+}
+
+func TestMarkdownCommitTable(t *testing.T) {
+	commit := testCommit(3, "Fix a | b")
+	commit.Description = "First line\nSecond line"
+	data := testReportData(commit, testCommit(2, "Add login form"))
+
+	markdown := renderMarkdown(t, data)
+	for _, want := range []string{
+		"| Data | SHA | Opis |\n| :---: | :---: | --- |\n",
+		"| 2024-01-03 | `33333333` | **Fix a \\| b**<br>First line<br>Second line |\n",
+		"| 2024-01-02 | `22222222` | **Add login form** |\n",
+		"## Podsumowanie\n\n- Łączna liczba commitów: **2**\n",
+		"Suma kontrolna commitów: `",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected %q in:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "Brak commitów") {
+		t.Errorf("unexpected empty note with commits:\n%s", markdown)
+	}
+}
+
+func TestMarkdownEmptyReport(t *testing.T) {
+	markdown := renderMarkdown(t, testReportData())
+
+	if !strings.Contains(markdown, "_Brak commitów w podanym okresie._") {
+		t.Errorf("expected the empty note:\n%s", markdown)
+	}
+	if strings.Contains(markdown, "| Data |") {
+		t.Errorf("unexpected commit table without commits:\n%s", markdown)
+	}
+}
+
+func TestMarkdownGenerateWritesFile(t *testing.T) {
+	data := testReportData(testCommit(2, "Add login form"))
+	path := filepath.Join(t.TempDir(), "report.md")
+
+	if err := NewMarkdownGenerator().Generate(data, path); err != nil {
+		t.Fatalf("failed to generate Markdown: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if want := renderMarkdown(t, data); string(content) != want {
+		t.Errorf("file content differs from Write:\n%s", content)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing", "report.md")
+	if err := NewMarkdownGenerator().Generate(data, missing); err == nil {
+		t.Error("expected an error for a missing output directory")
+	}
+}
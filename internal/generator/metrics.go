@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+// activityStreaks returns the number of distinct days with at least one commit
+// and the longest run of consecutive such days. Days are taken in the zone
+// returned by toZone.
+func activityStreaks(commits []*git.Commit, toZone func(time.Time) time.Time) (activeDays, longestStreak int) {
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	for _, commit := range commits {
+		day := calendarDay(toZone(commit.Date))
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	longestStreak = 1
+	streak := 1
+	for i := 1; i < len(days); i++ {
+		if days[i].Sub(days[i-1]) == 24*time.Hour {
+			streak++
+		} else {
+			streak = 1
+		}
+		if streak > longestStreak {
+			longestStreak = streak
+		}
+	}
+
+	return len(days), longestStreak
+}
+
+// calendarRow is a row of the commit table: either a commit or a day of the
+// report range on which no commit was made
+type calendarRow struct {
+	day    time.Time
+	commit *git.Commit // nil for an empty day
+}
+
+// tableRows returns the rows of the commit table, with the empty days of the
+// report range filled in when requested
+func tableRows(data *ReportData) []calendarRow {
+	if !data.Config.PDF.IncludeEmptyDays {
+		rows := make([]calendarRow, len(data.Commits))
+		for i, commit := range data.Commits {
+			rows[i] = calendarRow{day: calendarDay(data.displayTime(commit.Date)), commit: commit}
+		}
+		return rows
+	}
+	return calendarRows(data.Commits, data.DateFrom, data.DateTo, data.displayTime)
+}
+
+// calendarRows interleaves the commits with rows for the days between from
+// and to (inclusive) without any commit. The commit order is kept and the
+// empty days follow the same direction.
+func calendarRows(commits []*git.Commit, from, to time.Time, toZone func(time.Time) time.Time) []calendarRow {
+	var days []time.Time
+	for day := calendarDay(from); !day.After(calendarDay(to)); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	ascending := len(commits) > 1 && commits[0].Date.Before(commits[len(commits)-1].Date)
+	if !ascending {
+		for i, j := 0, len(days)-1; i < j; i, j = i+1, j-1 {
+			days[i], days[j] = days[j], days[i]
+		}
+	}
+	precedes := func(a, b time.Time) bool {
+		if ascending {
+			return a.Before(b)
+		}
+		return a.After(b)
+	}
+
+	var rows []calendarRow
+	next := 0
+	for _, commit := range commits {
+		day := calendarDay(toZone(commit.Date))
+		for next < len(days) && precedes(days[next], day) {
+			rows = append(rows, calendarRow{day: days[next]})
+			next++
+		}
+		// The commit's own day is not empty
+		for next < len(days) && days[next].Equal(day) {
+			next++
+		}
+		rows = append(rows, calendarRow{day: day, commit: commit})
+	}
+	for ; next < len(days); next++ {
+		rows = append(rows, calendarRow{day: days[next]})
+	}
+
+	return rows
+}
+
+// calendarDay returns midnight UTC of the calendar day of t in its own zone
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// activityColor returns the color of the highest threshold reached by the
+// given commit count
+func activityColor(thresholds []config.ActivityThreshold, count int) ([3]int, bool) {
+	var color [3]int
+	best := -1
+	for _, threshold := range thresholds {
+		if count >= threshold.MinCommits && threshold.MinCommits > best {
+			best = threshold.MinCommits
+			color = threshold.Color
+		}
+	}
+	return color, best >= 0
+}
+
+// fileTypeStat holds the lines inserted and deleted in files of one type
+type fileTypeStat struct {
+	fileType   string
+	insertions int
+	deletions  int
+}
+
+// fileTypeStats aggregates the per-file line statistics of the commits by
+// file extension, files without an extension counting as "other". The types
+// are ordered by the number of changed lines, most first.
+func fileTypeStats(commits []*git.Commit) []fileTypeStat {
+	byType := make(map[string]*fileTypeStat)
+	var stats []*fileTypeStat
+	for _, commit := range commits {
+		for _, file := range commit.FileStats {
+			fileType := strings.ToLower(path.Ext(file.Path))
+			if fileType == "" {
+				fileType = "other"
+			}
+			stat, ok := byType[fileType]
+			if !ok {
+				stat = &fileTypeStat{fileType: fileType}
+				byType[fileType] = stat
+				stats = append(stats, stat)
+			}
+			stat.insertions += file.Insertions
+			stat.deletions += file.Deletions
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.insertions+a.deletions != b.insertions+b.deletions {
+			return a.insertions+a.deletions > b.insertions+b.deletions
+		}
+		return a.fileType < b.fileType
+	})
+
+	result := make([]fileTypeStat, len(stats))
+	for i, stat := range stats {
+		result[i] = *stat
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+func TestActivityStreaksAcrossGap(t *testing.T) {
+	var commits []*git.Commit
+	for _, day := range []int{7, 2, 3, 3, 4, 6} {
+		commits = append(commits, testCommit(day, "Change"))
+	}
+
+	activeDays, longest := activityStreaks(commits, func(t time.Time) time.Time { return t })
+	if activeDays != 5 {
+		t.Errorf("active days = %d, want 5", activeDays)
+	}
+	if longest != 3 {
+		t.Errorf("longest streak = %d, want 3", longest)
+	}
+}
+
+func TestIncludeEmptyDaysFillsMiddleDay(t *testing.T) {
+	data := testReportData(testCommit(5, "Second change"), testCommit(3, "First change"))
+	data.DateFrom = time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	data.DateTo = time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	data.Config.PDF.IncludeEmptyDays = true
+
+	var got []string
+	for _, row := range tableRows(data) {
+		label := row.day.Format("2006-01-02") + " "
+		if row.commit == nil {
+			label += "empty"
+		} else {
+			label += row.commit.Message
+		}
+		got = append(got, label)
+	}
+	want := []string{"2024-01-05 Second change", "2024-01-04 empty", "2024-01-03 First change"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+
+	// The empty day is rendered as its own row of the table
+	items := pdfText(t, renderPDF(t, data))
+	if row := findText(t, items, emptyDayText); rowText(items, row.y, "2024-01-") != "2024-01-04" {
+		t.Errorf("empty row is not dated 2024-01-04")
+	}
+}
+
+func TestActivityColorPicksHighestReachedThreshold(t *testing.T) {
+	thresholds := []config.ActivityThreshold{
+		{MinCommits: 3, Color: [3]int{10, 200, 30}},
+		{MinCommits: 1, Color: [3]int{200, 200, 200}},
+	}
+	for count, want := range map[int][3]int{1: {200, 200, 200}, 2: {200, 200, 200}, 3: {10, 200, 30}, 7: {10, 200, 30}} {
+		color, ok := activityColor(thresholds, count)
+		if !ok || color != want {
+			t.Errorf("%d commits: color = %v (%v), want %v", count, color, ok, want)
+		}
+	}
+	if _, ok := activityColor(thresholds, 0); ok {
+		t.Error("a day without commits was highlighted")
+	}
+}
+
+func TestBusyDaySubtotalGetsThresholdColor(t *testing.T) {
+	busy := []*git.Commit{testCommit(5, "One"), testCommit(5, "Two"), testCommit(5, "Three")}
+	data := testReportData(append(busy, testCommit(3, "Quiet day"))...)
+	data.Config.PDF.GroupBy = config.GroupByDay
+	data.Config.PDF.ActivityThresholds = []config.ActivityThreshold{{MinCommits: 3, Color: [3]int{10, 200, 30}}}
+	streams := string(bytes.Join(pdfPageStreams(t, renderPDF(t, data)), nil))
+
+	// gofpdf writes the fill color as RGB fractions before the filled cell
+	if got := strings.Count(streams, "0.039 0.784 0.118 rg"); got != 1 {
+		t.Errorf("threshold color set %d times, want once for the busy day", got)
+	}
+}
+
+func TestFileTypeStatsOfTwoFileTypes(t *testing.T) {
+	code := testCommit(3, "Add handler")
+	code.FileStats = []git.FileStat{
+		{Path: "cmd/server.go", Insertions: 30, Deletions: 5},
+		{Path: "README.md", Insertions: 4, Deletions: 1},
+	}
+	docs := testCommit(4, "Document handler")
+	docs.FileStats = []git.FileStat{
+		{Path: "docs/API.MD", Insertions: 10},
+		{Path: "internal/handler.go", Insertions: 2, Deletions: 2},
+		{Path: "Makefile", Insertions: 1},
+	}
+
+	got := fileTypeStats([]*git.Commit{code, docs})
+	want := []fileTypeStat{
+		{fileType: ".go", insertions: 32, deletions: 7},
+		{fileType: ".md", insertions: 14, deletions: 1},
+		{fileType: "other", insertions: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("file type stats = %+v, want %+v", got, want)
+	}
+}
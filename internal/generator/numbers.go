@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// formatNumber formats an integer for display. With localized numbers the
+// digits are grouped by thousands in the style of the report language, e.g.
+// "1 234" in pl and "1,234" in en.
+func (d *ReportData) formatNumber(n int) string {
+	digits := strconv.Itoa(n)
+	if !d.Config.LocalizedNumbers {
+		return digits
+	}
+
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	separator := lookupLocale(d.Config.Lang).thousandsSeparator
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(separator)
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String()
+}
+
+// formatSigned formats a number with an explicit sign, e.g. "+12" or "-42"
+func (d *ReportData) formatSigned(n int) string {
+	if n > 0 {
+		return "+" + d.formatNumber(n)
+	}
+	return d.formatNumber(n)
+}
+
+// formatHours formats an effort estimate with two decimals, e.g. "1.25", or
+// "1,25" with localized Polish numbers
+func (d *ReportData) formatHours(hours float64) string {
+	text := strconv.FormatFloat(hours, 'f', 2, 64)
+	if !d.Config.LocalizedNumbers {
+		return text
+	}
+	whole, fraction, _ := strings.Cut(text, ".")
+	n, _ := strconv.Atoi(whole)
+	return d.formatNumber(n) + lookupLocale(d.Config.Lang).decimalSeparator + fraction
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/config"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		lang      string
+		localized bool
+		n         int
+		want      string
+	}{
+		{"pl", false, 1234567, "1234567"},
+		{"pl", true, 1234567, "1\u00a0234\u00a0567"},
+		{"en", true, 1234567, "1,234,567"},
+		{"en", true, -1234, "-1,234"},
+		{"en", true, 123, "123"},
+		{"en", true, 0, "0"},
+	}
+	for _, tt := range tests {
+		data := testReportData()
+		data.Config.Lang = tt.lang
+		data.Config.LocalizedNumbers = tt.localized
+		if got := data.formatNumber(tt.n); got != tt.want {
+			t.Errorf("formatNumber(%d) in %s = %q, want %q", tt.n, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFormatHoursDecimalSeparator(t *testing.T) {
+	data := testReportData()
+	if got := data.formatHours(1234.5); got != "1234.50" {
+		t.Errorf("formatHours = %q, want 1234.50", got)
+	}
+
+	data.Config.LocalizedNumbers = true
+	data.Config.Lang = "pl"
+	if got := data.formatHours(1234.5); got != "1\u00a0234,50" {
+		t.Errorf("formatHours in pl = %q, want 1 234,50", got)
+	}
+	data.Config.Lang = "en"
+	if got := data.formatHours(1234.5); got != "1,234.50" {
+		t.Errorf("formatHours in en = %q, want 1,234.50", got)
+	}
+}
+
+func TestLargeTotalRenderedWithSeparator(t *testing.T) {
+	commit := testCommit(3, "Generate fixtures")
+	commit.Insertions, commit.NetLines = 12345, 12345
+	data := testReportData(commit)
+	data.Config.Stats.WithStats = true
+	data.Config.LocalizedNumbers = true
+	data.Config.Lang = config.DefaultConfig().Lang
+
+	text := pdfJoinedText(t, renderPDF(t, data))
+	if !strings.Contains(text, "Zmiana netto linii: +12\u00a0345") {
+		t.Errorf("expected the net lines with a thousands separator:\n%s", text)
+	}
+}
@@ -1,14 +1,18 @@
 package generator
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 	"time"
 
+	"git-report-generator/fonts"
 	"git-report-generator/internal/config"
 	"git-report-generator/internal/git"
 
@@ -16,10 +20,14 @@ import (
 )
 
 const (
-	fontDir  = "fonts"
-	fontName = "DejaVu"
-	fontFile = "DejaVuSans.ttf"
-	fontBold = "DejaVuSans-Bold.ttf"
+	fontDir    = "fonts"
+	fontName   = "DejaVu"
+	fontFile   = "DejaVuSans.ttf"
+	fontBold   = "DejaVuSans-Bold.ttf"
+	fontItalic = "DejaVuSans-Oblique.ttf"
+
+	// Placeholder text of calendar rows for days without commits
+	emptyDayText = "—"
 )
 
 // ReportData contains all the data needed to generate a report
@@ -28,15 +36,67 @@ type ReportData struct {
 	RepositoryName string
 	RepositoryPath string // Absolute path to the repository
 	BranchName     string
+	Branches       []string // Branches the commits were collected from, in flag order
+	HeadHash       string   // Full hash of the branch tip the report was generated against
+	ChainVerified  bool     // Every commit was verified to be reachable from HeadHash
+	AllRefs        bool     // Commits were collected from all branches and tags
+	RemoteURL      string   // URL of the origin remote, empty without remotes
 	AuthorEmail    string
+	AuthorEmails   []string // All author email filters, several with a repeated --author
+	AuthorName     string   // Author name filter, if any
 	DateFrom       time.Time
 	DateTo         time.Time
 	Commits        []*git.Commit
+	Collapsed      int  // Number of duplicate commits removed by DedupeByMessage
+	OmittedAuthors int  // Number of authors dropped by DropSparseAuthors
+	MinCommits     int  // Threshold authors were dropped below
+	PrettyJSON     bool // Indent the JSON output for reading
+	CSVBOM         bool // Start the CSV output with a UTF-8 byte order mark
+
+	// Totals of the preceding period of equal length, nil unless compared
+	Previous *PeriodTotals
+
+	// Tags ordered by date, sectioning release-grouped reports
+	Releases []git.Tag
+
+	// Parameters the commit set was selected with, embedded in the PDF
+	// metadata so the checksum can be verified later
+	ProofParams url.Values
+}
+
+// displayTime converts t to the zone used for rendering dates in the report
+func (d *ReportData) displayTime(t time.Time) time.Time {
+	if d.Config.PDF.DisplayUTC {
+		return t.UTC()
+	}
+	return t
 }
 
 // PDFGenerator handles PDF report generation
 type PDFGenerator struct {
 	pdf *gofpdf.Fpdf
+
+	// Page number on which each commit's table entry ended, in commit order
+	commitPages []int
+
+	// Rendered disclaimer text, empty when none is configured
+	disclaimer string
+
+	// Glyph coverage of the primary font and the configured fallback faces,
+	// both empty when no fallback fonts are configured
+	primaryRunes map[rune]bool
+	fallbacks    []fallbackFace
+
+	// Factor the commit table font and rows are shrunk by to fit the page
+	// cap, 0 when rendering at the configured size
+	fontScale float64
+
+	// Vertical alignment of the table cells, from pdf.cell_valign
+	cellVAlign string
+}
+
+func init() {
+	Register(NewPDFGenerator())
 }
 
 // NewPDFGenerator creates a new PDF generator
@@ -44,14 +104,27 @@ func NewPDFGenerator() *PDFGenerator {
 	return &PDFGenerator{}
 }
 
+// Format returns the output format name of the generator
+func (g *PDFGenerator) Format() string {
+	return "pdf"
+}
+
+// DefaultFontDir returns the directory next to the executable the PDF
+// fonts are loaded from
+func DefaultFontDir() (string, error) {
+	executablePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
+	}
+	return filepath.Join(filepath.Dir(executablePath), fontDir), nil
+}
+
 func (g *PDFGenerator) getAbsFontPath(fontFileName string) (string, error) {
 	executablePath, err := os.Executable()
 	if err != nil {
 		return "", fmt.Errorf("failed to get executable path: %w", err)
 	}
-	fmt.Printf("Executable path: %s\n", executablePath)
 	executableDir := filepath.Dir(executablePath)
-	fmt.Printf("Executable dir: %s\n", executableDir)
 	// Construct path relative to executable dir first
 	relPath := filepath.Join(fontDir, fontFileName)
 	// Then join with executable dir and ensure it's absolute and clean
@@ -59,43 +132,117 @@ func (g *PDFGenerator) getAbsFontPath(fontFileName string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to get absolute font path for %s: %w", relPath, err)
 	}
-	fmt.Printf("Constructed absolute font path: %s\n", absPath)
 	return absPath, nil
 }
 
+// fontBytes returns the font file from the font directory next to the
+// executable, or the embedded copy when it is not installed there
+func (g *PDFGenerator) fontBytes(name string) ([]byte, error) {
+	path, err := g.getAbsFontPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get font path: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		content, err = fonts.FS.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded font %s: %w", name, err)
+		}
+		return content, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font %s: %w", path, err)
+	}
+	return content, nil
+}
+
 // Generate creates a PDF report based on the provided data
 func (g *PDFGenerator) Generate(data *ReportData, outputPath string) error {
-	executablePath, _ := os.Executable()
-	executableDir := filepath.Dir(executablePath)
-	absFontDir := filepath.Join(executableDir, fontDir)
-	fmt.Printf("Absolute font directory for gofpdf.New: %s\n", absFontDir)
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create PDF file: %w", err)
+	}
+
+	if err := g.GenerateTo(data, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to save PDF: %w", err)
+	}
+	return nil
+}
 
-	regularFontPath, err := g.getAbsFontPath(fontFile)
+// GenerateTo renders the PDF report and writes it to w, which allows
+// streaming to stdout or keeping the document in memory
+func (g *PDFGenerator) GenerateTo(data *ReportData, w io.Writer) error {
+	if err := g.renderFitted(data); err != nil {
+		return err
+	}
+	if err := g.pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// Write renders the PDF report to the given writer
+func (g *PDFGenerator) Write(data *ReportData, out io.Writer) error {
+	return g.GenerateTo(data, out)
+}
+
+// render builds the PDF document in memory
+func (g *PDFGenerator) render(data *ReportData) error {
+	regularFont, err := g.fontBytes(fontFile)
 	if err != nil {
-		return fmt.Errorf("failed to get font path: %w", err)
+		return err
 	}
-	boldFontPath, err := g.getAbsFontPath(fontBold)
+	boldFont, err := g.fontBytes(fontBold)
 	if err != nil {
-		return fmt.Errorf("failed to get bold font path: %w", err)
+		return err
+	}
+	italicFont, err := g.fontBytes(fontItalic)
+	if err != nil {
+		return err
 	}
 
-	g.pdf = gofpdf.New("P", "mm", "A4", absFontDir)
-	fmt.Printf("Passing to AddUTF8Font (Regular): '%s'\n", regularFontPath)
-	g.pdf.AddUTF8Font(fontName, "", fontFile)
-	fmt.Printf("Passing to AddUTF8Font (Bold): '%s'\n", boldFontPath)
-	g.pdf.AddUTF8Font(fontName, "B", fontBold)
-	// Register italic font
-	italicFontFile := "DejaVuSans-Oblique.ttf"
-	italicFontPath, err := g.getAbsFontPath(italicFontFile)
+	// The fonts are added from memory, so gofpdf needs no font directory
+	g.pdf = gofpdf.New("P", "mm", "A4", "")
+	g.commitPages = nil
+	g.cellVAlign = data.Config.PDF.CellVAlign
+	g.pdf.AddUTF8FontFromBytes(fontName, "", regularFont)
+	g.pdf.AddUTF8FontFromBytes(fontName, "B", boldFont)
+	g.pdf.AddUTF8FontFromBytes(fontName, "I", italicFont)
+	if err := g.loadFallbackFonts(regularFont, data.Config.PDF.FallbackFonts); err != nil {
+		return err
+	}
+	g.disclaimer, err = renderTemplate("disclaimer", data.Config.Footer.Disclaimer, headerTemplateData(data))
 	if err != nil {
-		return fmt.Errorf("failed to get italic font path: %w", err)
+		return err
 	}
-	fmt.Printf("Passing to AddUTF8Font (Italic): '%s'\n", italicFontPath)
-	g.pdf.AddUTF8Font(fontName, "I", italicFontFile)
-	g.pdf.SetFont(fontName, "", 11)
+	if g.disclaimer != "" && data.Config.Footer.DisclaimerEveryPage {
+		g.pdf.SetFooterFunc(func() {
+			g.pdf.SetY(-data.Config.PDF.MarginBottom + 2)
+			g.renderDisclaimer(data)
+		})
+	}
+
+	g.pdf.SetKeywords(proofKeywords(data), false)
+	g.pdf.SetHeaderFuncMode(func() { g.decoratePage(data) }, true)
+
+	margins := data.Config.PDF
+	g.pdf.SetMargins(margins.MarginLeft, margins.MarginTop, margins.MarginRight)
+	g.pdf.SetAutoPageBreak(true, margins.MarginBottom)
+	g.pdf.SetFont(fontName, "", data.Config.PDF.FontSizes.Body)
 	g.pdf.AddPage()
-	g.pdf.SetMargins(20, 20, 20)
-	g.pdf.SetAutoPageBreak(true, 20)
+
+	if data.Config.PDF.CoverPage {
+		if err := g.generateCover(data); err != nil {
+			return err
+		}
+		g.pdf.AddPage()
+	}
 
 	if err := g.generateHeader(data); err != nil {
 		return err
@@ -103,89 +250,70 @@ func (g *PDFGenerator) Generate(data *ReportData, outputPath string) error {
 	if err := g.generateCommits(data); err != nil {
 		return err
 	}
-	if err := g.pdf.OutputFileAndClose(outputPath); err != nil {
-		return fmt.Errorf("failed to save PDF: %w", err)
-	}
 	return nil
 }
 
-// generateHeader creates the header section of the PDF
-func (g *PDFGenerator) generateHeader(data *ReportData) error {
-	// Parse template to extract components
-	lines := strings.Split(data.Config.Header.Template, "\n")
-	if len(lines) < 2 {
-		return fmt.Errorf("invalid template format: not enough lines")
-	}
-
-	// Extract key parts
-	dateLocLine := lines[0] // "Kraków, {{.date_from}} - {{.date_to}}"
-	titleLine := lines[1]   // "Protokół odbioru prac programistycznych"
-
-	// Rest of the template (excluding first two lines)
-	restOfTemplate := strings.Join(lines[2:], "\n")
-
-	// Prepare template data
-	templateData := map[string]interface{}{
-		"executor_name":   data.Config.Header.ExecutorName,
-		"executor_email":  data.Config.Header.ExecutorEmail,
-		"recipient_name":  data.Config.Header.RecipientName,
-		"repository_name": data.RepositoryName,
-		"repository_path": data.RepositoryPath,
-		"branch_name":     data.BranchName,
-		"date_from":       data.DateFrom.Format("2006-01-02"),
-		"date_to":         data.DateTo.Format("2006-01-02"),
-	}
-
-	// Debug: Print template parts
-	fmt.Println("DEBUG: Date line:", dateLocLine)
-	fmt.Println("DEBUG: Title line:", titleLine)
-	fmt.Println("DEBUG: Rest of template:", restOfTemplate)
-
-	// Render date line
-	dateTmpl, err := template.New("date").Option("missingkey=zero").Parse(dateLocLine)
-	if err != nil {
-		return fmt.Errorf("failed to parse date template: %w", err)
+// decoratePage draws the configured page background and border. It runs at
+// the start of every page, so both end up behind the page content.
+func (g *PDFGenerator) decoratePage(data *ReportData) {
+	pageWidth, pageHeight := g.pdf.GetPageSize()
+
+	// Pages can start in the middle of a table, so keep its drawing state
+	fillR, fillG, fillB := g.pdf.GetFillColor()
+	drawR, drawG, drawB := g.pdf.GetDrawColor()
+	lineWidth := g.pdf.GetLineWidth()
+	defer func() {
+		g.pdf.SetFillColor(fillR, fillG, fillB)
+		g.pdf.SetDrawColor(drawR, drawG, drawB)
+		g.pdf.SetLineWidth(lineWidth)
+	}()
+
+	if bg := data.Config.PDF.BackgroundColor; bg != nil {
+		g.pdf.SetFillColor(bg[0], bg[1], bg[2])
+		g.pdf.Rect(0, 0, pageWidth, pageHeight, "F")
 	}
-	var dateBuf bytes.Buffer
-	if err := dateTmpl.Execute(&dateBuf, templateData); err != nil {
-		return fmt.Errorf("failed to execute date template: %w", err)
+
+	if data.Config.PDF.PageBorder {
+		// Halfway into the margins, so the frame never touches the content
+		margins := data.Config.PDF
+		g.pdf.SetDrawColor(120, 120, 120)
+		g.pdf.SetLineWidth(0.3)
+		g.pdf.Rect(margins.MarginLeft/2, margins.MarginTop/2,
+			pageWidth-(margins.MarginLeft+margins.MarginRight)/2,
+			pageHeight-(margins.MarginTop+margins.MarginBottom)/2, "D")
 	}
-	dateText := dateBuf.String()
+}
 
-	// Render rest of template
-	restTmpl, err := template.New("rest").Option("missingkey=zero").Parse(restOfTemplate)
+// generateHeader creates the header section of the PDF
+func (g *PDFGenerator) generateHeader(data *ReportData) error {
+	header, err := renderHeader(data)
 	if err != nil {
-		return fmt.Errorf("failed to parse rest of template: %w", err)
-	}
-	var restBuf bytes.Buffer
-	if err := restTmpl.Execute(&restBuf, templateData); err != nil {
-		return fmt.Errorf("failed to execute rest of template: %w", err)
+		return err
 	}
-	restText := restBuf.String()
+	dateText, titleLine, restText := header.DateLine, header.Title, header.Body
 
-	// Debug: Print rendered components
-	fmt.Println("DEBUG: Rendered date:", dateText)
-	fmt.Println("DEBUG: Title (not rendered):", titleLine) // Title doesn't need rendering, no variables
-	fmt.Println("DEBUG: Rendered rest:", restText)
+	sizes := data.Config.PDF.FontSizes
 
 	// Generate PDF layout
+	if data.Config.Header.Logo != "" {
+		if err := g.drawLogo(data); err != nil {
+			return err
+		}
+	}
+
 	// 1. Date line at normal size
-	g.pdf.SetFont(fontName, "", 11)
+	g.pdf.SetFont(fontName, "", sizes.Body)
 	g.pdf.Cell(0, 10, dateText)
 	g.pdf.Ln(12)
 
-	// 2. Title line larger, bold, and centered
-	g.pdf.SetFont(fontName, "B", 16)
-	titleWidth := g.pdf.GetStringWidth(titleLine)
-	pageWidth, _ := g.pdf.GetPageSize()
-	leftMargin, _, rightMargin, _ := g.pdf.GetMargins()
-	availableWidth := pageWidth - leftMargin - rightMargin
-	xPos := (availableWidth - titleWidth) / 2
-	g.pdf.Cell(0, 8, titleLine)
+	// 2. Title line larger, bold, and aligned as configured
+	g.pdf.SetFont(fontName, "B", sizes.Title)
+	g.pdf.SetX(g.leftMargin())
+	g.pdf.CellFormat(g.availableWidth(), 8, titleLine, "", 0, data.Config.PDF.TitleAlign, false, 0, "")
 	g.pdf.Ln(15)
 
 	// 3. Rest of the template
-	g.pdf.SetFont(fontName, "", 11)
+	g.pdf.SetFont(fontName, "", sizes.Body)
 	g.pdf.MultiCell(0, 7, restText, "", "L", false)
 	g.pdf.Ln(5)
 	return nil
@@ -193,44 +321,566 @@ func (g *PDFGenerator) generateHeader(data *ReportData) error {
 
 // generateCommits creates the commits section of the PDF
 func (g *PDFGenerator) generateCommits(data *ReportData) error {
+	sizes := data.Config.PDF.FontSizes
+
 	if len(data.Commits) == 0 {
-		g.pdf.SetFont(fontName, "I", 11)
+		g.pdf.SetFont(fontName, "I", sizes.Body)
 		g.pdf.Cell(0, 6, "Brak commitów w podanym okresie.")
+		g.pdf.Ln(10)
+		g.generateDisclaimer(data)
 		return nil
 	}
 
+	if data.Config.PDF.GroupBy != "" {
+		g.generateGroupSections(data)
+	} else {
+		g.generateCommitTable(data)
+	}
+
+	if data.Config.PDF.ShowSummary {
+		g.generateSummary(data)
+	} else {
+		g.pdf.Ln(8)
+	}
+	g.generateFooter(data)
+
+	if data.Config.PDF.ShowHeatmap {
+		g.generateHeatmap(data)
+	}
+	if data.Config.PDF.ShowLeaderboard {
+		g.generateLeaderboard(data)
+	}
+	return nil
+}
+
+// generateCommitTable renders the commits in the configured table layout
+func (g *PDFGenerator) generateCommitTable(data *ReportData) {
+	if data.Config.PDF.Compact {
+		g.generateCompactTable(data)
+	} else {
+		g.generateTable(data)
+	}
+}
+
+// generateGroupSections renders a separate commit table and subtotal for
+// every author or day. The commits are expected to be ordered by GroupCommits.
+func (g *PDFGenerator) generateGroupSections(data *ReportData) {
+	sizes := data.Config.PDF.FontSizes
+	runs := data.groupRuns()
+
+	// Day sections only hold their own day, the empty days of the range are
+	// listed once between them
+	byDay := data.Config.PDF.GroupBy == config.GroupByDay
+	var emptyDays [][]time.Time
+	if byDay && data.Config.PDF.IncludeEmptyDays {
+		emptyDays = data.emptyDaysBetween(len(runs))
+	}
+
+	for i, commits := range runs {
+		if i > 0 {
+			g.pdf.Ln(6)
+		}
+		if emptyDays != nil {
+			g.emptyDayLines(data, emptyDays[i])
+		}
+
+		first := commits[0]
+		heading := fmt.Sprintf("Autor: %s", AuthorLabel(first.Author, data.displayEmail(first.AuthorEmail)))
+		switch data.Config.PDF.GroupBy {
+		case config.GroupByDay:
+			heading = fmt.Sprintf("Dzień: %s", data.displayTime(first.Date).Format("2006-01-02"))
+		case config.GroupByRelease:
+			heading = fmt.Sprintf("Wydanie: %s", data.releaseLabel(first))
+		case config.GroupByBranch:
+			heading = fmt.Sprintf("Gałąź: %s", first.Branch)
+		}
+		g.pdf.SetFont(fontName, "B", sizes.Body)
+		if data.Config.PDF.AuthorColors && data.Config.PDF.GroupBy == config.GroupByAuthor {
+			color := authorColor(data.Config.Authors, first.AuthorEmail)
+			g.pdf.SetTextColor(color[0], color[1], color[2])
+		}
+		link := ""
+		if data.Config.PDF.GroupBy == config.GroupByAuthor {
+			link = data.mailtoLink(first.AuthorEmail)
+		}
+		g.pdf.CellFormat(0, 8, heading, "", 1, "L", false, 0, link)
+		g.pdf.SetTextColor(0, 0, 0)
+
+		section := *data
+		section.Commits = commits
+		if byDay {
+			day := calendarDay(data.displayTime(first.Date))
+			section.DateFrom, section.DateTo = day, day
+		}
+		g.generateCommitTable(&section)
+
+		g.pdf.SetFont(fontName, "I", sizes.Table)
+		subtotal := fmt.Sprintf("Liczba commitów: %s", data.formatNumber(len(commits)))
+		if data.Config.Stats.WithStats {
+			net := 0
+			for _, commit := range commits {
+				net += commit.NetLines
+			}
+			subtotal += fmt.Sprintf(", zmiana netto linii: %s", data.formatSigned(net))
+		}
+		color, highlighted := activityColor(data.Config.PDF.ActivityThresholds, len(commits))
+		if highlighted {
+			g.pdf.SetFillColor(color[0], color[1], color[2])
+		}
+		g.pdf.CellFormat(0, 6, subtotal, "", 1, "R", highlighted, 0, "")
+	}
+	if emptyDays != nil {
+		g.pdf.Ln(6)
+		g.emptyDayLines(data, emptyDays[len(runs)])
+	}
+}
+
+// emptyDayLines lists days without commits between the day sections
+func (g *PDFGenerator) emptyDayLines(data *ReportData, days []time.Time) {
+	if len(days) == 0 {
+		return
+	}
+	g.pdf.SetFont(fontName, "I", data.Config.PDF.FontSizes.Table)
+	for _, day := range days {
+		g.pdf.CellFormat(0, 6, fmt.Sprintf("Dzień: %s – brak commitów", day.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	}
+	g.pdf.Ln(2)
+}
+
+// generateTable renders the commit table with one row per commit
+func (g *PDFGenerator) generateTable(data *ReportData) {
+	sizes := data.Config.PDF.FontSizes
+
+	shaPolicy := data.Config.PDF.SHAColumnPolicy
+	g.pdf.SetFont(fontName, "", sizes.Table)
+	dateWidth := dateColWidth
+	if width := data.Config.PDF.Table.DateColWidth; width > 0 {
+		dateWidth = width
+	}
+	shaWidth := g.shaColumnWidth(data.Config.PDF.Table, shaPolicy, data.Commits)
+
+	withStats := data.Config.Stats.WithStats
+	netWidth := 0.0
+	if withStats {
+		netWidth = netColWidth
+		if data.Config.Stats.SplitLines {
+			netWidth = splitColWidth
+		}
+	}
+	withTickets := data.Config.CommitParsing.ShowTicketColumn
+	ticketWidth := 0.0
+	if withTickets {
+		ticketWidth = ticketColWidth
+	}
+	withPRs := data.hasPullRequestLinks()
+	prWidth := 0.0
+	if withPRs {
+		prWidth = prColWidth
+	}
+	withEffort := data.Config.Estimation.Enabled
+	effortWidth := 0.0
+	var estimates map[string]float64
+	if withEffort {
+		effortWidth = effortColWidth
+		estimates = effortEstimates(data.Commits, data.Config.Estimation)
+	}
+
+	// Shrink columns and font alike so measured widths such as the SHA stay valid
+	tableSize := sizes.Table
+	available := g.availableWidth()
+	// Columns enabled by the data, such as pull requests, can still crowd
+	// out the description, which is then fitted like with auto-fit
+	fixed := dateWidth + shaWidth + ticketWidth + prWidth + netWidth + effortWidth
+	if data.Config.PDF.AutoFitTable || fixed >= available {
+		if scale := autoFitScale(fixed, available); scale < 1 {
+			dateWidth *= scale
+			shaWidth *= scale
+			ticketWidth *= scale
+			prWidth *= scale
+			netWidth *= scale
+			effortWidth *= scale
+			tableSize *= scale
+		}
+	}
+	descWidth := available - dateWidth - shaWidth - ticketWidth - prWidth - netWidth - effortWidth
+
+	lineHeight := 7.0
+	if g.fontScale > 0 {
+		tableSize *= g.fontScale
+		lineHeight *= g.fontScale
+	}
+
+	// Table header
+	g.pdf.SetFont(fontName, "B", tableSize)
+	g.pdf.SetFillColor(220, 220, 220)
+	g.pdf.CellFormat(dateWidth, 8, "Data", "1", 0, "C", true, 0, "")
+	g.pdf.CellFormat(shaWidth, 8, "SHA", "1", 0, "C", true, 0, "")
+	if withTickets {
+		g.pdf.CellFormat(ticketWidth, 8, "Zgłoszenie", "1", 0, "C", true, 0, "")
+	}
+	if withPRs {
+		g.pdf.CellFormat(prWidth, 8, "PR", "1", 0, "C", true, 0, "")
+	}
+	g.pdf.CellFormat(descWidth, 8, "Opis", "1", 0, "C", true, 0, "")
+	if withStats {
+		g.pdf.CellFormat(netWidth, 8, data.linesHeading(), "1", 0, "C", true, 0, "")
+	}
+	if withEffort {
+		g.pdf.CellFormat(effortWidth, 8, "Czas [h]", "1", 0, "C", true, 0, "")
+	}
+	g.pdf.Ln(-1)
+
+	g.pdf.SetFont(fontName, "", tableSize)
+	for i, row := range tableRows(data) {
+		if i%2 == 1 {
+			g.pdf.SetFillColor(245, 245, 245)
+		} else {
+			g.pdf.SetFillColor(255, 255, 255)
+		}
+
+		commit := row.commit
+		if commit == nil {
+			cells := []tableCell{
+				{width: dateWidth, text: row.day.Format("2006-01-02"), align: "C"},
+				{width: shaWidth, text: "", align: "C"},
+			}
+			if withTickets {
+				cells = append(cells, tableCell{width: ticketWidth, text: "", align: "C"})
+			}
+			if withPRs {
+				cells = append(cells, tableCell{width: prWidth, text: "", align: "C"})
+			}
+			cells = append(cells, tableCell{width: descWidth, text: emptyDayText, align: "L"})
+			if withStats {
+				cells = append(cells, tableCell{width: netWidth, text: "", align: "R"})
+			}
+			if withEffort {
+				cells = append(cells, tableCell{width: effortWidth, text: "", align: "R"})
+			}
+			g.drawRow(cells, lineHeight)
+			continue
+		}
+
+		prose, codeBlocks := commit.Description, []string(nil)
+		if data.Config.PDF.RenderCodeBlocks {
+			prose, codeBlocks = splitCodeBlocks(commit.Body)
+		}
+		description := g.clipLines(prose, descWidth, data.Config.PDF.MaxDescriptionLines)
+		if commit.Note != "" {
+			description = strings.TrimPrefix(description+"\nNotatka: "+commit.Note, "\n")
+		}
+		if data.Config.CommitParsing.ShowSignOffs && len(commit.SignOffs) > 0 {
+			description = strings.TrimPrefix(description+"\nSign-offs: "+strings.Join(commit.SignOffs, ", "), "\n")
+		}
+		cells := []tableCell{
+			{width: dateWidth, text: row.day.Format("2006-01-02"), align: "C"},
+			g.shaCell(shaPolicy, commit.SHA, shaWidth),
+		}
+		if withTickets {
+			cells = append(cells, tableCell{width: ticketWidth, text: commit.Ticket, align: "C", wrap: true})
+		}
+		if withPRs {
+			cells = append(cells, data.pullRequestCell(commit, prWidth))
+		}
+		cells = append(cells, tableCell{width: descWidth, subject: commit.Message, text: description, align: "L", wrap: true})
+		if withStats {
+			cells = append(cells, tableCell{width: netWidth, text: data.linesText(commit), align: "R"})
+		}
+		if withEffort {
+			cells = append(cells, tableCell{width: effortWidth, text: data.formatHours(estimates[commit.Hash]), align: "R"})
+		}
+		g.drawRow(cells, lineHeight)
+
+		for _, block := range codeBlocks {
+			g.renderMonospace(block, tableSize)
+		}
+
+		if commit.Diff != "" {
+			g.renderMonospace(commit.Diff, tableSize)
+		} else if commit.DiffLines > data.Config.Stats.MaxDiffLines {
+			g.pdf.SetFont(fontName, "I", tableSize-1)
+			g.pdf.CellFormat(0, 6, fmt.Sprintf("Diff pominięty (%s zmienionych linii)", data.formatNumber(commit.DiffLines)), "1", 1, "L", false, 0, "")
+			g.pdf.SetFont(fontName, "", tableSize)
+		}
+		g.commitPages = append(g.commitPages, g.pdf.PageNo())
+	}
+}
+
+// generateCompactTable renders one single-line row per commit, without
+// descriptions or diffs, using a smaller font to fit more rows per page
+func (g *PDFGenerator) generateCompactTable(data *ReportData) {
+	// Two points below the regular table font, but at least half of it so
+	// small configured sizes stay positive
+	tableSize := data.Config.PDF.FontSizes.Table
+	fontSize := math.Max(tableSize-2, tableSize/2)
+	const rowHeight = 5.0
+
+	subjectWidth := g.availableWidth() - 22 - 18
+
 	// Table header
-	g.pdf.SetFont(fontName, "B", 10)
+	g.pdf.SetFont(fontName, "B", fontSize)
 	g.pdf.SetFillColor(220, 220, 220)
-	g.pdf.CellFormat(30, 8, "Data", "1", 0, "C", true, 0, "")
-	g.pdf.CellFormat(25, 8, "SHA", "1", 0, "C", true, 0, "")
-	g.pdf.CellFormat(0, 8, "Opis", "1", 1, "C", true, 0, "")
+	g.pdf.CellFormat(22, rowHeight+1, "Data", "1", 0, "C", true, 0, "")
+	g.pdf.CellFormat(18, rowHeight+1, "SHA", "1", 0, "C", true, 0, "")
+	g.pdf.CellFormat(subjectWidth, rowHeight+1, "Temat", "1", 1, "C", true, 0, "")
 
-	g.pdf.SetFont(fontName, "", 10)
-	for i, commit := range data.Commits {
+	g.pdf.SetFont(fontName, "", fontSize)
+	for i, row := range tableRows(data) {
 		if i%2 == 1 {
 			g.pdf.SetFillColor(245, 245, 245)
 		} else {
 			g.pdf.SetFillColor(255, 255, 255)
 		}
-		g.pdf.CellFormat(30, 7, commit.Date.Format("2006-01-02"), "1", 0, "C", true, 0, "")
-		g.pdf.CellFormat(25, 7, commit.SHA, "1", 0, "C", true, 0, "")
-		g.pdf.MultiCell(0, 7, fmt.Sprintf("%s\n%s", commit.Message, commit.Description), "1", "L", false)
+
+		commit := row.commit
+		if commit == nil {
+			g.pdf.CellFormat(22, rowHeight, row.day.Format("2006-01-02"), "1", 0, "C", true, 0, "")
+			g.pdf.CellFormat(18, rowHeight, "", "1", 0, "C", true, 0, "")
+			g.pdf.CellFormat(subjectWidth, rowHeight, emptyDayText, "1", 1, "L", true, 0, "")
+			continue
+		}
+
+		subject := g.fitText(commit.Message, subjectWidth)
+		g.pdf.CellFormat(22, rowHeight, row.day.Format("2006-01-02"), "1", 0, "C", true, 0, "")
+		g.pdf.CellFormat(18, rowHeight, commit.SHA, "1", 0, "C", true, 0, "")
+		g.pdf.CellFormat(subjectWidth, rowHeight, subject, "1", 1, "L", true, 0, "")
+		g.commitPages = append(g.commitPages, g.pdf.PageNo())
 	}
+}
+
+// generateSummary renders the summary block below the commit table
+func (g *PDFGenerator) generateSummary(data *ReportData) {
+	sizes := data.Config.PDF.FontSizes
 
 	g.pdf.Ln(8)
-	g.pdf.SetFont(fontName, "B", 11)
+	g.pdf.SetFont(fontName, "B", sizes.Summary+1)
 	g.pdf.Cell(0, 8, "Podsumowanie:")
 	g.pdf.Ln(8)
-	g.pdf.SetFont(fontName, "", 10)
-	g.pdf.Cell(0, 6, fmt.Sprintf("Łączna liczba commitów: %d", len(data.Commits)))
+	g.pdf.SetFont(fontName, "", sizes.Summary)
+	g.pdf.Cell(0, 6, fmt.Sprintf("Łączna liczba commitów: %s", data.formatNumber(len(data.Commits))))
 	g.pdf.Ln(6)
-	g.pdf.Cell(0, 6, fmt.Sprintf("Autor: %s", data.AuthorEmail))
+	if data.Collapsed > 0 {
+		g.pdf.Cell(0, 6, fmt.Sprintf("Pominięte duplikaty (ta sama treść): %s", data.formatNumber(data.Collapsed)))
+		g.pdf.Ln(6)
+	}
+	if empty := countNoMessage(data.Commits); empty > 0 {
+		g.pdf.Cell(0, 6, fmt.Sprintf("Commity bez opisu: %s", data.formatNumber(empty)))
+		g.pdf.Ln(6)
+	}
+	if data.Config.PDF.GroupBy == config.GroupByAuthor {
+		g.pdf.Cell(0, 6, fmt.Sprintf("Liczba autorów: %s", data.formatNumber(len(data.groupRuns()))))
+		if data.OmittedAuthors > 0 {
+			g.pdf.Ln(6)
+			g.pdf.Cell(0, 6, fmt.Sprintf("Pominięci autorzy (mniej niż %s commitów): %s", data.formatNumber(data.MinCommits), data.formatNumber(data.OmittedAuthors)))
+		}
+	} else if len(data.AuthorEmails) > 1 {
+		g.pdf.Cell(0, 6, "Autorzy:")
+		for _, count := range data.authorCounts() {
+			g.pdf.Ln(6)
+			g.pdf.CellFormat(0, 6, fmt.Sprintf("  %s: %s", count.label, data.formatNumber(count.commits)), "", 0, "L", false, 0, data.mailtoLink(count.email))
+		}
+	} else {
+		g.pdf.CellFormat(0, 6, fmt.Sprintf("Autor: %s", data.summaryAuthor()), "", 0, "L", false, 0, data.mailtoLink(data.summaryEmail()))
+	}
 	g.pdf.Ln(6)
-	g.pdf.Cell(0, 6, fmt.Sprintf("Okres: %s - %s", data.DateFrom.Format("2006-01-02"), data.DateTo.Format("2006-01-02")))
-	g.pdf.Ln(10)
-	g.pdf.SetFont(fontName, "I", 8)
+	g.pdf.Cell(0, 6, fmt.Sprintf("Okres: %s", data.periodText()))
+	g.pdf.Ln(6)
+	if data.Config.Stats.WithStats {
+		net, insertions, deletions := 0, 0, 0
+		for _, commit := range data.Commits {
+			net += commit.NetLines
+			insertions += commit.Insertions
+			deletions += commit.Deletions
+		}
+		g.pdf.Cell(0, 6, fmt.Sprintf("Zmiana netto linii: %s", data.formatSigned(net)))
+		g.pdf.Ln(6)
+		g.pdf.Cell(0, 6, fmt.Sprintf("Dodane / usunięte linie: +%s / -%s", data.formatNumber(insertions), data.formatNumber(deletions)))
+		g.pdf.Ln(6)
+	}
+	if previous := data.Previous; previous != nil {
+		current := Totals(data.Commits, data.DateFrom, data.DateTo)
+		g.pdf.Cell(0, 6, fmt.Sprintf("Zmiana względem poprzedniego okresu (%s - %s, %s commitów):",
+			data.displayTime(previous.From).Format("2006-01-02"), data.displayTime(previous.To).Format("2006-01-02"), data.formatNumber(previous.Commits)))
+		g.pdf.Ln(6)
+		g.pdf.Cell(0, 6, fmt.Sprintf("  commity %s, dodane linie %s, usunięte linie %s",
+			formatChange(current.Commits, previous.Commits),
+			formatChange(current.Insertions, previous.Insertions),
+			formatChange(current.Deletions, previous.Deletions)))
+		g.pdf.Ln(6)
+	}
+	if data.Config.Estimation.Enabled {
+		effort := totalEffort(data.Commits, effortEstimates(data.Commits, data.Config.Estimation))
+		g.pdf.Cell(0, 6, fmt.Sprintf("Szacowany nakład pracy: %s h", data.formatHours(effort)))
+		g.pdf.Ln(6)
+	}
+	if data.Config.Stats.ByteStats {
+		var added, removed int64
+		for _, commit := range data.Commits {
+			added += commit.BytesAdded
+			removed += commit.BytesRemoved
+		}
+		g.pdf.Cell(0, 6, fmt.Sprintf("Zmiana rozmiaru plików: +%s B / -%s B", data.formatNumber(int(added)), data.formatNumber(int(removed))))
+		g.pdf.Ln(6)
+	}
+	if data.Config.Stats.FileTypeStats {
+		g.pdf.Cell(0, 6, "Zmiany według typu plików:")
+		g.pdf.Ln(6)
+		for _, stat := range fileTypeStats(data.Commits) {
+			g.pdf.Cell(0, 6, fmt.Sprintf("  %s: +%s / -%s", stat.fileType, data.formatNumber(stat.insertions), data.formatNumber(stat.deletions)))
+			g.pdf.Ln(6)
+		}
+	}
+	if data.HeadHash != "" {
+		g.pdf.Cell(0, 6, fmt.Sprintf("Stan repozytorium: %s", data.HeadHash))
+		g.pdf.Ln(6)
+	}
+
+	if data.Config.PDF.ShowStreaks {
+		activeDays, longestStreak := activityStreaks(data.Commits, data.displayTime)
+		g.pdf.Cell(0, 6, fmt.Sprintf("Dni aktywności: %s", data.formatNumber(activeDays)))
+		g.pdf.Ln(6)
+		g.pdf.Cell(0, 6, fmt.Sprintf("Najdłuższa seria: %s dni", data.formatNumber(longestStreak)))
+		g.pdf.Ln(6)
+	}
+	g.pdf.Ln(4)
+}
+
+// countNoMessage returns the number of commits with an empty message
+func countNoMessage(commits []*git.Commit) int {
+	count := 0
+	for _, commit := range commits {
+		if commit.NoMessage {
+			count++
+		}
+	}
+	return count
+}
+
+// generateFooter renders the generation timestamp at the end of the report
+func (g *PDFGenerator) generateFooter(data *ReportData) {
+	g.generateDisclaimer(data)
+
+	g.pdf.SetFont(fontName, "I", data.Config.PDF.FontSizes.Footer)
 	g.pdf.SetTextColor(120, 120, 120)
-	g.pdf.Cell(0, 4, fmt.Sprintf("Raport wygenerowany: %s", time.Now().Format("2006-01-02 15:04:05")))
-	return nil
+	generatedAt := data.displayTime(time.Now()).Format("2006-01-02 15:04:05")
+	if data.Config.PDF.DisplayUTC {
+		generatedAt += " UTC"
+	}
+	g.pdf.Cell(0, 4, fmt.Sprintf("Suma kontrolna commitów: %s", CommitSetDigest(data.Commits)))
+	g.pdf.Ln(4)
+	if data.ChainVerified && len(data.HeadHash) >= 7 {
+		g.pdf.Cell(0, 4, fmt.Sprintf("Łańcuch commitów zweryfikowany: wszystkie osiągalne z %s", data.HeadHash[:7]))
+		g.pdf.Ln(4)
+	}
+	if data.AllRefs {
+		g.pdf.Cell(0, 4, "Zakres: commity ze wszystkich gałęzi i tagów repozytorium")
+		g.pdf.Ln(4)
+	}
+	g.pdf.Cell(0, 4, fmt.Sprintf("Raport wygenerowany: %s", generatedAt))
+}
+
+// generateDisclaimer renders the disclaimer at the end of the report, unless
+// it is already repeated in the footer of every page
+func (g *PDFGenerator) generateDisclaimer(data *ReportData) {
+	if g.disclaimer == "" || data.Config.Footer.DisclaimerEveryPage {
+		return
+	}
+	g.renderDisclaimer(data)
+	g.pdf.Ln(2)
+}
+
+// renderDisclaimer writes the disclaimer in small gray text at the current position
+func (g *PDFGenerator) renderDisclaimer(data *ReportData) {
+	g.pdf.SetFont(fontName, "", data.Config.PDF.FontSizes.Footer)
+	g.pdf.SetTextColor(120, 120, 120)
+	g.pdf.MultiCell(0, 3.5, g.disclaimer, "", "L", false)
+	g.pdf.SetTextColor(0, 0, 0)
+}
+
+// renderMonospace draws text such as a unified diff or a code block in a
+// light-grey monospace box spanning the table width
+func (g *PDFGenerator) renderMonospace(diff string, fontSize float64) {
+	// Courier is a core font, so text has to be translated from UTF-8
+	tr := g.pdf.UnicodeTranslatorFromDescriptor("")
+	text := strings.ReplaceAll(strings.TrimRight(diff, "\n"), "\t", "    ")
+
+	g.pdf.SetFont("Courier", "", fontSize-2)
+	g.pdf.SetFillColor(240, 240, 240)
+	g.pdf.MultiCell(0, 4, tr(text), "1", "L", true)
+	g.pdf.SetFont(fontName, "", fontSize)
+}
+
+// summaryAuthor returns the author shown in the summary in the configured
+// display mode. Without a name or email filter the name and email of the
+// first commit are used.
+func (d *ReportData) summaryAuthor() string {
+	name := d.AuthorName
+	if name == "" && len(d.Commits) > 0 {
+		name = d.Commits[0].Author
+	}
+	email := d.displayEmail(d.summaryEmail())
+
+	switch d.Config.Summary.AuthorDisplay {
+	case config.AuthorDisplayName:
+		if name != "" {
+			return name
+		}
+		return email
+	case config.AuthorDisplayNameEmail:
+		return AuthorLabel(name, email)
+	default:
+		// The name filter has always been shown along with the email
+		return AuthorLabel(d.AuthorName, d.displayEmail(d.AuthorEmail))
+	}
+}
+
+// authorCount is the number of commits of one of several filtered authors
+type authorCount struct {
+	label   string
+	email   string
+	commits int
+}
+
+// authorCounts returns the commit counts of the filtered authors in the
+// order they were given, labeled with the name of their first commit
+func (d *ReportData) authorCounts() []authorCount {
+	counts := make([]authorCount, len(d.AuthorEmails))
+	for i, email := range d.AuthorEmails {
+		name := ""
+		for _, commit := range d.Commits {
+			if strings.EqualFold(commit.AuthorEmail, email) {
+				if name == "" {
+					name = commit.Author
+				}
+				counts[i].commits++
+			}
+		}
+		counts[i].label = AuthorLabel(name, d.displayEmail(email))
+		counts[i].email = email
+	}
+	return counts
+}
+
+// summaryEmail returns the full email of the author shown in the summary, or
+// "" when the summary shows no email
+func (d *ReportData) summaryEmail() string {
+	if d.Config.Summary.AuthorDisplay != config.AuthorDisplayName && d.Config.Summary.AuthorDisplay != config.AuthorDisplayNameEmail {
+		return d.AuthorEmail
+	}
+	if d.AuthorEmail == "" && len(d.Commits) > 0 {
+		return d.Commits[0].AuthorEmail
+	}
+	return d.AuthorEmail
+}
+
+// authorLabel describes the author filter as "Name <email>", "Name" or "email"
+func AuthorLabel(name, email string) string {
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
+		return name
+	default:
+		return email
+	}
 }
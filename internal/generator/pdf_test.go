@@ -0,0 +1,434 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+func TestTableFontSizeOnlyAffectsTableRows(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	data.Config.PDF.FontSizes.Table = 7
+	items := pdfText(t, renderPDF(t, data))
+
+	if row := findText(t, items, "Add login form"); row.size != 7 {
+		t.Errorf("table row font size = %v, want 7", row.size)
+	}
+	sizes := data.Config.PDF.FontSizes
+	if title := findText(t, items, "Protokół odbioru"); title.size != sizes.Title {
+		t.Errorf("title font size = %v, want %v", title.size, sizes.Title)
+	}
+	if body := findText(t, items, "Repozytorium: repo"); body.size != sizes.Body {
+		t.Errorf("header body font size = %v, want %v", body.size, sizes.Body)
+	}
+	if summary := findText(t, items, "Łączna liczba commitów"); summary.size != sizes.Summary {
+		t.Errorf("summary font size = %v, want %v", summary.size, sizes.Summary)
+	}
+	if footer := findText(t, items, "Suma kontrolna"); footer.size != sizes.Footer {
+		t.Errorf("footer font size = %v, want %v", footer.size, sizes.Footer)
+	}
+}
+
+func TestDisplayUTCRendersCommitDatesInUTC(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*3600)
+	tokyo := time.FixedZone("JST", 9*3600)
+	evening := testCommit(3, "Evening in New York")
+	evening.Date = time.Date(2024, 1, 3, 23, 30, 0, 0, newYork)
+	morning := testCommit(5, "Morning in Tokyo")
+	morning.Date = time.Date(2024, 1, 5, 1, 0, 0, 0, tokyo)
+
+	for _, tc := range []struct {
+		displayUTC bool
+		want       []string
+	}{
+		{false, []string{"2024-01-03", "2024-01-05"}},
+		{true, []string{"2024-01-04", "2024-01-04"}},
+	} {
+		data := testReportData(evening, morning)
+		data.Config.PDF.DisplayUTC = tc.displayUTC
+		items := pdfText(t, renderPDF(t, data))
+
+		for i, subject := range []string{"Evening in New York", "Morning in Tokyo"} {
+			row := findText(t, items, subject)
+			date := rowText(items, row.y, "2024-01-")
+			if date != tc.want[i] {
+				t.Errorf("display UTC %v: %q rendered on %q, want %q", tc.displayUTC, subject, date, tc.want[i])
+			}
+		}
+	}
+}
+
+func TestDiffsEmbeddedForSmallCommitsAndOmittedForLarge(t *testing.T) {
+	tiny := testCommit(3, "Tiny change")
+	tiny.Diff = "--- a/small.txt\n+++ b/small.txt\n@@ -1 +1 @@\n-one\n+two\n"
+	tiny.DiffLines = 2
+	large := testCommit(4, "Large change")
+	large.DiffLines = 500
+
+	data := testReportData(tiny, large)
+	data.Config.Stats.MaxDiffLines = 100
+	content := renderPDF(t, data)
+
+	// The monospace block uses the single-byte Courier core font
+	streams := string(bytes.Join(pdfPageStreams(t, content), nil))
+	if !strings.Contains(streams, "(+two)Tj") || !strings.Contains(streams, "(-one)Tj") {
+		t.Error("diff of the tiny commit not embedded")
+	}
+	if text := pdfJoinedText(t, content); !strings.Contains(text, "Diff pominięty (500 zmienionych linii)") {
+		t.Errorf("omitted diff note missing:\n%s", text)
+	}
+}
+
+func TestCompactLayoutOmitsDescriptionColumn(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.Description = "Validates the password on submit"
+
+	data := testReportData(commit)
+	data.Config.PDF.Compact = true
+	content := renderPDF(t, data)
+	items := pdfText(t, content)
+	text := pdfJoinedText(t, content)
+
+	if strings.Contains(text, "Opis") || strings.Contains(text, "Validates the password") {
+		t.Errorf("compact layout rendered the description column:\n%s", text)
+	}
+	if !strings.Contains(text, "Temat") {
+		t.Errorf("compact layout is missing the subject column:\n%s", text)
+	}
+	if row := findText(t, items, "Add login form"); row.size >= data.Config.PDF.FontSizes.Table {
+		t.Errorf("compact row font size = %v, want below %v", row.size, data.Config.PDF.FontSizes.Table)
+	}
+}
+
+func TestTitleAlignmentSetsXOffset(t *testing.T) {
+	const ptPerMM = 72 / 25.4
+	x := make(map[string]float64)
+	for _, align := range []string{"L", "C", "R"} {
+		data := testReportData(testCommit(3, "Add login form"))
+		data.Config.PDF.TitleAlign = align
+		x[align] = findText(t, pdfText(t, renderPDF(t, data)), "Protokół odbioru").x
+	}
+
+	// gofpdf keeps a cell margin of a tenth of the default 1cm page margin
+	left := (config.DefaultConfig().PDF.MarginLeft + 1) * ptPerMM
+	if math.Abs(x["L"]-left) > 0.01 {
+		t.Errorf("left-aligned title at x = %.2f, want %.2f", x["L"], left)
+	}
+	if !(x["L"] < x["C"] && x["C"] < x["R"]) {
+		t.Errorf("title offsets L %.2f, C %.2f, R %.2f are not increasing", x["L"], x["C"], x["R"])
+	}
+	// The margins are symmetric, so the centered title lies halfway
+	if math.Abs((x["R"]-x["L"])-2*(x["C"]-x["L"])) > 0.01 {
+		t.Errorf("centered title at x = %.2f is not halfway between %.2f and %.2f", x["C"], x["L"], x["R"])
+	}
+}
+
+func TestNetLinesColumnShowsNegativeNumbers(t *testing.T) {
+	trim := testCommit(3, "Trim module")
+	trim.Insertions, trim.Deletions, trim.NetLines = 2, 44, -42
+	grow := testCommit(4, "Add module")
+	grow.Insertions, grow.NetLines = 10, 10
+
+	data := testReportData(trim, grow)
+	data.Config.Stats.WithStats = true
+	items := pdfText(t, renderPDF(t, data))
+
+	for subject, want := range map[string]string{"Trim module": "-42", "Add module": "+10"} {
+		row := findText(t, items, subject)
+		if rowText(items, row.y, want) != want {
+			t.Errorf("%q row does not show net lines %s", subject, want)
+		}
+	}
+	if total := findText(t, items, "Zmiana netto linii"); total.text != "Zmiana netto linii: -32" {
+		t.Errorf("summary total = %q, want \"Zmiana netto linii: -32\"", total.text)
+	}
+}
+
+func TestGenerateToBufferYieldsValidPDF(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+
+	var buf bytes.Buffer
+	if err := NewPDFGenerator().GenerateTo(data, &buf); err != nil {
+		t.Fatalf("GenerateTo failed: %v", err)
+	}
+	content := buf.Bytes()
+	if !bytes.HasPrefix(content, []byte("%PDF-1.")) {
+		t.Errorf("output does not start with a PDF header: %q", content[:min(len(content), 16)])
+	}
+	if !bytes.HasSuffix(bytes.TrimRight(content, "\r\n"), []byte("%%EOF")) {
+		t.Error("output does not end with the PDF trailer")
+	}
+	if !bytes.Contains(content, []byte("/Type /Pages")) || !bytes.Contains(content, []byte("xref")) {
+		t.Error("output is missing the page tree or cross-reference table")
+	}
+	if text := pdfJoinedText(t, content); !strings.Contains(text, "Add login form") {
+		t.Errorf("commit missing from the PDF:\n%s", text)
+	}
+
+	// Generate writes the same document to a file
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := NewPDFGenerator().Generate(data, path); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	file, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read generated PDF: %v", err)
+	}
+	if pdfJoinedText(t, file) != pdfJoinedText(t, content) {
+		t.Error("the file and the buffer hold different text")
+	}
+}
+
+func TestMaxDescriptionLinesClipsLongDescription(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.Description = strings.Repeat("The form validates the password and shows an error message. ", 12)
+
+	for _, maxLines := range []int{0, 2} {
+		data := testReportData(commit)
+		data.Config.PDF.MaxDescriptionLines = maxLines
+		items := pdfText(t, renderPDF(t, data))
+
+		// Description lines are drawn below the subject in the same column
+		subject := findText(t, items, "Add login form")
+		var lines []string
+		for _, item := range items {
+			if item.x == subject.x && item.y < subject.y {
+				lines = append(lines, item.text)
+			}
+		}
+
+		if maxLines == 0 {
+			if len(lines) <= 2 {
+				t.Fatalf("unclipped description has %d lines, want more than 2 for the test", len(lines))
+			}
+			continue
+		}
+		if len(lines) != maxLines {
+			t.Errorf("clipped description has %d lines, want %d: %q", len(lines), maxLines, lines)
+		} else if !strings.HasSuffix(lines[maxLines-1], "…") {
+			t.Errorf("last kept line %q does not end with an ellipsis", lines[maxLines-1])
+		}
+	}
+}
+
+func TestDisclaimerRenderedWithTemplateVariables(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	data.Config.Footer.Disclaimer = "Protokół dotyczy repozytorium {{.repository_name}}.\nStrony potwierdzają odbiór prac."
+	items := pdfText(t, renderPDF(t, data))
+
+	first := findText(t, items, "Protokół dotyczy repozytorium repo.")
+	second := findText(t, items, "Strony potwierdzają odbiór prac.")
+	if first.size != data.Config.PDF.FontSizes.Footer {
+		t.Errorf("disclaimer font size = %v, want %v", first.size, data.Config.PDF.FontSizes.Footer)
+	}
+	if second.y >= first.y {
+		t.Error("disclaimer lines are not rendered one below the other")
+	}
+}
+
+func TestSubjectAndDescriptionRenderAsSeparateBlocks(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.Description = "Validates the password on submit"
+	items := pdfText(t, renderPDF(t, testReportData(commit)))
+
+	subject := findText(t, items, "Add login form")
+	description := findText(t, items, "Validates the password on submit")
+	if subject.text != "Add login form" || description.text != "Validates the password on submit" {
+		t.Errorf("subject %q and description %q are not drawn separately", subject.text, description.text)
+	}
+	if subject.font == description.font {
+		t.Errorf("subject and description share font %s, want a bold subject", subject.font)
+	}
+	if subject.x != description.x || description.y >= subject.y {
+		t.Error("description is not rendered beneath the subject")
+	}
+
+	// The subject uses the same bold font as the table header
+	if header := findText(t, items, "Opis"); subject.font != header.font {
+		t.Errorf("subject font %s is not the bold header font %s", subject.font, header.font)
+	}
+}
+
+func TestTicketColumnShowsStrippedPrefix(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.Ticket = "JIRA-123"
+	data := testReportData(commit)
+	data.Config.CommitParsing.ShowTicketColumn = true
+	items := pdfText(t, renderPDF(t, data))
+
+	findText(t, items, "Zgłoszenie")
+	if row := findText(t, items, "Add login form"); rowText(items, row.y, "JIRA-") != "JIRA-123" {
+		t.Error("ticket not shown on the commit row")
+	}
+}
+
+func TestNoSummaryOmitsSummaryBlock(t *testing.T) {
+	for _, show := range []bool{true, false} {
+		data := testReportData(testCommit(3, "Add login form"))
+		data.Config.PDF.ShowSummary = show
+		text := pdfJoinedText(t, renderPDF(t, data))
+
+		for _, line := range []string{"Podsumowanie:", "Łączna liczba commitów"} {
+			if strings.Contains(text, line) != show {
+				t.Errorf("show summary %v: %q present = %v", show, line, !show)
+			}
+		}
+		if !strings.Contains(text, "Add login form") {
+			t.Errorf("show summary %v: commit table missing", show)
+		}
+	}
+}
+
+func TestPageBorderDrawsFrameOnEveryPage(t *testing.T) {
+	const k = 72 / 25.4
+	var commits []*git.Commit
+	for i := 0; i < 60; i++ {
+		commits = append(commits, testCommit(i%28+1, fmt.Sprintf("Change %d", i)))
+	}
+
+	for _, border := range []bool{false, true} {
+		data := testReportData(commits...)
+		data.Config.PDF.PageBorder = border
+		pages := pdfPageStreams(t, renderPDF(t, data))
+		if len(pages) < 2 {
+			t.Fatalf("report has %d pages, want at least 2 for the test", len(pages))
+		}
+
+		// Halfway into the margins of the 595.28 x 841.89pt A4 page
+		m := data.Config.PDF
+		frame := fmt.Sprintf("%.2f %.2f %.2f %.2f re S",
+			m.MarginLeft/2*k, 841.89-m.MarginTop/2*k,
+			595.28-(m.MarginLeft+m.MarginRight)/2*k, -(841.89 - (m.MarginTop+m.MarginBottom)/2*k))
+		for i, page := range pages {
+			if got := bytes.Contains(page, []byte(frame)); got != border {
+				t.Errorf("border %v: page %d has frame %q = %v", border, i+1, frame, got)
+			}
+		}
+	}
+}
+
+func TestCommitNoteRenderedBelowDescription(t *testing.T) {
+	annotated := testCommit(3, "Annotated")
+	annotated.Note = "Reviewed by QA"
+	text := pdfJoinedText(t, renderPDF(t, testReportData(annotated, testCommit(4, "Plain"))))
+
+	if strings.Count(text, "Notatka: ") != 1 || !strings.Contains(text, "Notatka: Reviewed by QA") {
+		t.Errorf("note not rendered once for the annotated commit:\n%s", text)
+	}
+}
+
+func TestSummaryAuthorDisplay(t *testing.T) {
+	tests := []struct {
+		display string
+		want    string
+	}{
+		{config.AuthorDisplayEmail, "jan@example.com"},
+		{config.AuthorDisplayName, "Jan Kowalski"},
+		{config.AuthorDisplayNameEmail, "Jan Kowalski <jan@example.com>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.display, func(t *testing.T) {
+			data := testReportData(testCommit(3, "one"))
+			data.Config.Summary.AuthorDisplay = tt.display
+
+			if got := data.summaryAuthor(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			want := "Autor: " + tt.want
+			if !strings.Contains(pdfJoinedText(t, renderPDF(t, data)), want) {
+				t.Errorf("expected %q in the PDF", want)
+			}
+		})
+	}
+}
+
+func TestSummaryPerAuthorCounts(t *testing.T) {
+	data := testReportData(annaCommit(5, "Anna second"), testCommit(4, "Jan only"), annaCommit(3, "Anna first"))
+	data.AuthorEmails = []string{"jan@example.com", "ANNA@example.com", "ewa@example.com"}
+	text := pdfJoinedText(t, renderPDF(t, data))
+
+	for _, want := range []string{
+		"Autorzy:",
+		"Jan Kowalski <jan@example.com>: 1",
+		"Anna Nowak <ANNA@example.com>: 2",
+		"ewa@example.com: 0",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the summary:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Autor: ") {
+		t.Errorf("expected no single author line with several authors:\n%s", text)
+	}
+}
+
+func TestChainVerifiedStatement(t *testing.T) {
+	data := testReportData(testCommit(3, "one"))
+	want := "Łańcuch commitów zweryfikowany: wszystkie osiągalne z fffffff"
+	if strings.Contains(pdfJoinedText(t, renderPDF(t, data)), want) {
+		t.Error("expected no chain statement for an unverified commit set")
+	}
+
+	data.ChainVerified = true
+	if !strings.Contains(pdfJoinedText(t, renderPDF(t, data)), want) {
+		t.Errorf("expected %q in the PDF", want)
+	}
+}
+
+func TestShowSignOffs(t *testing.T) {
+	commit := testCommit(3, "Fix parser")
+	commit.SignOffs = []string{"Jan Kowalski <jan@example.com>"}
+	data := testReportData(commit)
+	want := "Sign-offs: Jan Kowalski <jan@example.com>"
+
+	if strings.Contains(pdfJoinedText(t, renderPDF(t, data)), want) {
+		t.Error("expected the sign-offs hidden by default")
+	}
+	data.Config.CommitParsing.ShowSignOffs = true
+	if !strings.Contains(pdfJoinedText(t, renderPDF(t, data)), want) {
+		t.Errorf("expected %q in the PDF", want)
+	}
+}
+
+func TestEmptyMessagePlaceholderRendered(t *testing.T) {
+	empty := testCommit(3, "(brak opisu)")
+	empty.NoMessage = true
+	data := testReportData(empty, testCommit(2, "Initial commit"))
+
+	items := pdfText(t, renderPDF(t, data))
+	row := findText(t, items, "(brak opisu)")
+	if date := rowText(items, row.y, "2024-"); date != "2024-01-03" {
+		t.Errorf("placeholder drawn in the row of %q, want 2024-01-03", date)
+	}
+	findText(t, items, "Commity bez opisu: 1")
+}
+
+func TestRenderWritesNothingToStdout(t *testing.T) {
+	read, write, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = write
+	defer func() { os.Stdout = stdout }()
+
+	renderPDF(t, testReportData(testCommit(3, "Add login form")))
+	os.Stdout = stdout
+	write.Close()
+
+	var output bytes.Buffer
+	if _, err := output.ReadFrom(read); err != nil {
+		t.Fatalf("failed to read stdout: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("rendering wrote to stdout:\n%s", output.String())
+	}
+}
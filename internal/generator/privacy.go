@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// RedactEmail masks the local part of an email address, keeping its first
+// character and the domain (e.g. "j***@corp.com")
+func RedactEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+
+	local, domain, found := strings.Cut(email, "@")
+	masked := "***"
+	if local != "" {
+		masked = string([]rune(local)[:1]) + masked
+	}
+	if !found {
+		return masked
+	}
+	return masked + "@" + domain
+}
+
+// EmailDigest returns the hex SHA-256 digest of the lowercased email, which
+// identifies an author without disclosing the address
+func EmailDigest(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return hex.EncodeToString(sum[:])
+}
+
+// mailtoLink returns a mailto: link for the email, or "" for an empty or
+// redacted email so that the link does not reveal the masked address
+func (d *ReportData) mailtoLink(email string) string {
+	if email == "" || d.Config.Privacy.RedactEmails {
+		return ""
+	}
+	return "mailto:" + email
+}
+
+// displayEmail returns the email as it should appear in the report, redacted
+// when the privacy configuration asks for it. Filtering always uses the full
+// address.
+func (d *ReportData) displayEmail(email string) string {
+	if d.Config.Privacy.RedactEmails {
+		return RedactEmail(email)
+	}
+	return email
+}
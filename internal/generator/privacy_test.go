@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/config"
+)
+
+func TestRedactEmailMasksLocalPart(t *testing.T) {
+	for email, want := range map[string]string{
+		"jan.kowalski@corp.com": "j***@corp.com",
+		"ł@example.pl":          "ł***@example.pl",
+		"@corp.com":             "***@corp.com",
+		"no-at-sign":            "n***",
+		"":                      "",
+	} {
+		if got := RedactEmail(email); got != want {
+			t.Errorf("RedactEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestRedactedReportHidesEmails(t *testing.T) {
+	data := testReportData(testCommit(3, "Add login form"))
+	data.Config.Privacy.RedactEmails = true
+	data.Config.Header.ExecutorEmail = "jan@example.com"
+	data.ProofParams = map[string][]string{"author": {"Jan@Example.com"}}
+
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := NewPDFGenerator().Generate(data, path); err != nil {
+		t.Fatalf("failed to generate PDF: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read PDF: %v", err)
+	}
+
+	text := pdfJoinedText(t, content)
+	if strings.Contains(strings.ToLower(text), "jan@example.com") {
+		t.Errorf("full email rendered in the redacted report:\n%s", text)
+	}
+	if !strings.Contains(text, "j***@example.com") {
+		t.Errorf("redacted email missing from the report:\n%s", text)
+	}
+
+	if strings.Contains(strings.ToLower(string(content)), "jan@example.com") {
+		t.Error("full email found in the PDF metadata")
+	}
+
+	// The proof metadata only records a digest of the author filter
+	proof, err := ReadProof(path)
+	if err != nil {
+		t.Fatalf("failed to read proof: %v", err)
+	}
+	if proof.Has("author") {
+		t.Errorf("proof metadata discloses the author filter: %v", proof)
+	}
+	if got, want := proof.Get("author_sha256"), EmailDigest("jan@example.com"); got != want {
+		t.Errorf("author digest = %q, want %q", got, want)
+	}
+}
+
+func TestMailtoLinks(t *testing.T) {
+	data := testReportData(testCommit(3, "one"))
+	data.Config.Summary.AuthorDisplay = config.AuthorDisplayNameEmail
+	if !bytes.Contains(renderPDF(t, data), []byte("/URI (mailto:jan@example.com)")) {
+		t.Error("expected a mailto link to the author in the summary")
+	}
+
+	data.Config.Privacy.RedactEmails = true
+	if content := renderPDF(t, data); bytes.Contains(content, []byte("mailto:")) {
+		t.Error("expected no mailto link for a redacted email")
+	}
+}
+
+func TestMailtoLinksInAuthorSections(t *testing.T) {
+	data := testReportData(testCommit(3, "one"), annaCommit(4, "two"))
+	data.Config.PDF.GroupBy = config.GroupByAuthor
+	data.Commits = GroupCommits(data)
+
+	content := renderPDF(t, data)
+	for _, link := range []string{"mailto:jan@example.com", "mailto:anna@example.com"} {
+		if !bytes.Contains(content, []byte("/URI ("+link+")")) {
+			t.Errorf("expected a %s link in the section headings", link)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ReportWriter renders a report in a specific output format
+type ReportWriter interface {
+	// Write renders the report to the given writer
+	Write(data *ReportData, out io.Writer) error
+
+	// Format returns the name the writer is registered under (e.g. "pdf")
+	Format() string
+}
+
+// writers holds the registered report writers keyed by format name
+var writers = make(map[string]ReportWriter)
+
+// Register makes a report writer available under its format name,
+// replacing any writer previously registered for the same format
+func Register(w ReportWriter) {
+	writers[strings.ToLower(w.Format())] = w
+}
+
+// Lookup returns the report writer registered for the given format
+func Lookup(format string) (ReportWriter, error) {
+	w, ok := writers[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported output format %q (available: %s)",
+			format, strings.Join(Formats(), ", "))
+	}
+	return w, nil
+}
+
+// Formats returns the sorted names of all registered formats
+func Formats() []string {
+	formats := make([]string, 0, len(writers))
+	for name := range writers {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
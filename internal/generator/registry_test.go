@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeWriter is a report writer recording the reports it renders
+type fakeWriter struct {
+	written []*ReportData
+}
+
+func (w *fakeWriter) Write(data *ReportData, out io.Writer) error {
+	w.written = append(w.written, data)
+	_, err := io.WriteString(out, "fake report")
+	return err
+}
+
+func (w *fakeWriter) Format() string {
+	return "fake"
+}
+
+func TestRegisterAndLookupFakeFormat(t *testing.T) {
+	fake := &fakeWriter{}
+	Register(fake)
+	t.Cleanup(func() { delete(writers, "fake") })
+
+	w, err := Lookup("FAKE")
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+
+	data := testReportData(testCommit(3, "Add login form"))
+	var out bytes.Buffer
+	if err := w.Write(data, &out); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if len(fake.written) != 1 || fake.written[0] != data {
+		t.Errorf("the fake writer did not receive the report")
+	}
+	if out.String() != "fake report" {
+		t.Errorf("output = %q, want %q", out.String(), "fake report")
+	}
+
+	found := false
+	for _, format := range Formats() {
+		found = found || format == "fake"
+	}
+	if !found {
+		t.Errorf("Formats() = %v, missing fake", Formats())
+	}
+}
+
+func TestLookupUnknownFormatListsFormats(t *testing.T) {
+	_, err := Lookup("odt")
+	if err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+	if !strings.Contains(err.Error(), "pdf") {
+		t.Errorf("error %q does not list the available formats", err)
+	}
+}
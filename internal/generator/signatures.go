@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"git-report-generator/internal/git"
+)
+
+// WriteSignatures writes a sidecar file containing the full hash and raw PGP
+// signature block of every signed commit, so signatures can be verified offline.
+// Unsigned commits are skipped. It returns the number of signatures written.
+func WriteSignatures(commits []*git.Commit, outputPath string) (int, error) {
+	content, count := signaturesText(commits)
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write signatures file: %w", err)
+	}
+
+	return count, nil
+}
+
+// signaturesText returns the signatures sidecar content and the number of
+// signatures it holds
+func signaturesText(commits []*git.Commit) (string, int) {
+	var sb strings.Builder
+	count := 0
+
+	for _, commit := range commits {
+		if commit.Signature == "" {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("commit %s\n", commit.Hash))
+		sb.WriteString(strings.TrimRight(commit.Signature, "\n"))
+		sb.WriteString("\n\n")
+		count++
+	}
+
+	return sb.String(), count
+}
@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/git"
+)
+
+func TestWriteSignaturesSkipsUnsignedCommits(t *testing.T) {
+	signed := testCommit(3, "Signed change")
+	signed.Signature = "-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n"
+	unsigned := testCommit(4, "Unsigned change")
+
+	path := filepath.Join(t.TempDir(), "report.signatures.txt")
+	count, err := WriteSignatures([]*git.Commit{signed, unsigned}, path)
+	if err != nil {
+		t.Fatalf("WriteSignatures failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read signatures file: %v", err)
+	}
+	text := string(content)
+	if !strings.Contains(text, "commit "+signed.Hash+"\n-----BEGIN PGP SIGNATURE-----") {
+		t.Errorf("signed commit missing from signatures file:\n%s", text)
+	}
+	if strings.Contains(text, unsigned.Hash) {
+		t.Errorf("unsigned commit written to signatures file:\n%s", text)
+	}
+}
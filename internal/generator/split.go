@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"git-report-generator/internal/git"
+)
+
+// SplitByPages partitions the report commits into consecutive parts so that
+// each part, rendered with its own header, fits within maxPages pages.
+// A single commit that does not fit on its own still forms a part.
+func (g *PDFGenerator) SplitByPages(data *ReportData, maxPages int) ([][]*git.Commit, error) {
+	if maxPages <= 0 {
+		return nil, fmt.Errorf("page limit must be positive")
+	}
+
+	var parts [][]*git.Commit
+	remaining := data.Commits
+
+	for len(remaining) > 0 {
+		partData := *data
+		partData.Commits = remaining
+		if err := g.render(&partData); err != nil {
+			return nil, err
+		}
+
+		fits := 0
+		for fits < len(g.commitPages) && g.commitPages[fits] <= maxPages {
+			fits++
+		}
+		if fits == 0 {
+			fits = 1
+		}
+
+		parts = append(parts, remaining[:fits])
+		remaining = remaining[fits:]
+	}
+
+	return parts, nil
+}
+
+// PartPath returns the file path for the given 1-based part of a split report,
+// e.g. "report.pdf" becomes "report-part2.pdf"
+func PartPath(outputPath string, part int) string {
+	ext := filepath.Ext(outputPath)
+	return fmt.Sprintf("%s-part%d%s", strings.TrimSuffix(outputPath, ext), part, ext)
+}
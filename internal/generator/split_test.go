@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"git-report-generator/internal/git"
+)
+
+func TestSplitByPagesPartsFitPageLimit(t *testing.T) {
+	var commits []*git.Commit
+	for i := 0; i < 120; i++ {
+		commits = append(commits, testCommit(i%28+1, fmt.Sprintf("Change %d", i)))
+	}
+	data := testReportData(commits...)
+
+	g := NewPDFGenerator()
+	if err := g.render(data); err != nil {
+		t.Fatalf("failed to render PDF: %v", err)
+	}
+	commitPages := g.commitPages[len(g.commitPages)-1]
+	if commitPages < 4 {
+		t.Fatalf("commit table spans %d pages, want at least 4 for the test", commitPages)
+	}
+
+	const maxPages = 2
+	parts, err := g.SplitByPages(data, maxPages)
+	if err != nil {
+		t.Fatalf("SplitByPages failed: %v", err)
+	}
+
+	// Every part but the first starts with its own header, so the parts need
+	// at least as many pages as the single report
+	if min := (commitPages + maxPages - 1) / maxPages; len(parts) < min {
+		t.Errorf("got %d parts, want at least %d", len(parts), min)
+	}
+
+	var joined []*git.Commit
+	for i, part := range parts {
+		partData := *data
+		partData.Commits = part
+		if err := g.render(&partData); err != nil {
+			t.Fatalf("failed to render part %d: %v", i+1, err)
+		}
+		if last := g.commitPages[len(g.commitPages)-1]; last > maxPages {
+			t.Errorf("part %d has commits on page %d, want at most %d", i+1, last, maxPages)
+		}
+		if i < len(parts)-1 && len(parts[i+1]) > 0 {
+			// The part could not take the next commit
+			partData.Commits = append(append([]*git.Commit{}, part...), parts[i+1][0])
+			if err := g.render(&partData); err != nil {
+				t.Fatalf("failed to render part %d: %v", i+1, err)
+			}
+			if last := g.commitPages[len(g.commitPages)-1]; last <= maxPages {
+				t.Errorf("part %d stops before a commit that still fits", i+1)
+			}
+		}
+		joined = append(joined, part...)
+	}
+
+	if len(joined) != len(commits) {
+		t.Fatalf("parts hold %d commits, want %d", len(joined), len(commits))
+	}
+	for i := range commits {
+		if joined[i] != commits[i] {
+			t.Fatalf("commit %d is out of order in the parts", i)
+		}
+	}
+}
+
+func TestPartPath(t *testing.T) {
+	if got := PartPath("out/report.pdf", 2); got != "out/report-part2.pdf" {
+		t.Errorf("PartPath = %q, want out/report-part2.pdf", got)
+	}
+}
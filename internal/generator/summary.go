@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// summaryAuthorRecord is the commit count of a single author in the summary sidecar
+type summaryAuthorRecord struct {
+	Author  string `json:"author"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+}
+
+// summaryRecord holds the aggregate metrics of a report in the summary
+// sidecar. Statistics are only present when they were collected.
+type summaryRecord struct {
+	Repository  string                `json:"repository"`
+	Branch      string                `json:"branch"`
+	HeadHash    string                `json:"head_hash"`
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	Commits     int                   `json:"commits"`
+	ActiveDays  int                   `json:"active_days"`
+	Authors     []summaryAuthorRecord `json:"authors"`
+	Insertions  *int                  `json:"insertions,omitempty"`
+	Deletions   *int                  `json:"deletions,omitempty"`
+	NetLines    *int                  `json:"net_lines,omitempty"`
+	EffortHours *float64              `json:"effort_hours,omitempty"`
+	Digest      string                `json:"digest"`
+}
+
+// SummaryPath returns the path of the summary sidecar of a report, e.g.
+// "report.pdf" becomes "report.pdf.summary.json"
+func SummaryPath(outputPath string) string {
+	return outputPath + ".summary.json"
+}
+
+// WriteSummary writes the aggregate metrics of the report as indented JSON
+// to outputPath
+func WriteSummary(data *ReportData, outputPath string) error {
+	activeDays, _ := activityStreaks(data.Commits, data.displayTime)
+	record := summaryRecord{
+		Repository: data.RepositoryName,
+		Branch:     data.BranchName,
+		HeadHash:   data.HeadHash,
+		From:       data.DateFrom.Format("2006-01-02"),
+		To:         data.DateTo.Format("2006-01-02"),
+		Commits:    len(data.Commits),
+		ActiveDays: activeDays,
+		Authors:    []summaryAuthorRecord{},
+		Digest:     CommitSetDigest(data.Commits),
+	}
+
+	for _, commits := range SplitByAuthor(data.Commits) {
+		record.Authors = append(record.Authors, summaryAuthorRecord{
+			Author:  commits[0].Author,
+			Email:   data.displayEmail(commits[0].AuthorEmail),
+			Commits: len(commits),
+		})
+	}
+
+	if data.Config.Stats.WithStats {
+		totals := Totals(data.Commits, data.DateFrom, data.DateTo)
+		net := totals.Insertions - totals.Deletions
+		record.Insertions = &totals.Insertions
+		record.Deletions = &totals.Deletions
+		record.NetLines = &net
+	}
+
+	if data.Config.Estimation.Enabled {
+		effort := totalEffort(data.Commits, effortEstimates(data.Commits, data.Config.Estimation))
+		record.EffortHours = &effort
+	}
+
+	content, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+	if err := os.WriteFile(outputPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write summary file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteSummary(t *testing.T) {
+	first := testCommit(3, "one")
+	first.Insertions, first.Deletions = 10, 4
+	second := annaCommit(3, "two")
+	third := testCommit(5, "three")
+	third.Insertions = 2
+	data := testReportData(first, second, third)
+	data.Config.Stats.WithStats = true
+
+	path := filepath.Join(t.TempDir(), SummaryPath("report.pdf"))
+	if err := WriteSummary(data, path); err != nil {
+		t.Fatalf("failed to write summary: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read summary: %v", err)
+	}
+
+	var record summaryRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		t.Fatalf("failed to parse summary: %v", err)
+	}
+	if record.Commits != 3 || record.ActiveDays != 2 || record.From != "2024-01-01" || record.To != "2024-01-31" {
+		t.Errorf("unexpected totals: %+v", record)
+	}
+	if record.Insertions == nil || *record.Insertions != 12 || *record.Deletions != 4 || *record.NetLines != 8 {
+		t.Errorf("unexpected line totals in %s", content)
+	}
+	if len(record.Authors) != 2 || record.Authors[0].Email != "jan@example.com" || record.Authors[0].Commits != 2 {
+		t.Errorf("unexpected authors: %+v", record.Authors)
+	}
+	if record.Digest != CommitSetDigest(data.Commits) {
+		t.Errorf("digest = %s, want the commit set digest", record.Digest)
+	}
+	if record.EffortHours != nil {
+		t.Error("expected no effort estimate unless enabled")
+	}
+}
+
+func TestSummaryPath(t *testing.T) {
+	if got := SummaryPath("out/report.pdf"); got != "out/report.pdf.summary.json" {
+		t.Errorf("SummaryPath = %q, want out/report.pdf.summary.json", got)
+	}
+}
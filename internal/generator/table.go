@@ -0,0 +1,293 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"git-report-generator/internal/config"
+	"git-report-generator/internal/git"
+)
+
+const (
+	// defaultSHAColWidth is the SHA column width used by the fixed-width policies
+	defaultSHAColWidth = config.DefaultSHAColWidth
+
+	// netColWidth is the width of the net lines column
+	netColWidth = config.NetColWidth
+
+	// splitColWidth is the width of the lines column with stats.split_lines
+	splitColWidth = config.SplitColWidth
+
+	// ticketColWidth is the width of the ticket column
+	ticketColWidth = config.TicketColWidth
+
+	// prColWidth is the width of the pull request column
+	prColWidth = 16.0
+
+	// effortColWidth is the width of the effort estimate column
+	effortColWidth = config.EffortColWidth
+
+	// dateColWidth is the default width of the date column
+	dateColWidth = config.DefaultDateColWidth
+
+	// minDescColWidth is the narrowest description column auto-fit keeps
+	// before scaling the whole table down
+	minDescColWidth = 40.0
+)
+
+// tableCell describes a single cell of a table row
+type tableCell struct {
+	width   float64
+	text    string
+	align   string
+	wrap    bool   // wrap text over several lines instead of keeping a single line
+	subject string // bold block rendered above text, wrapped like it
+	link    string // URL the whole cell links to, if any
+}
+
+// cellLine is a single rendered line of a table cell
+type cellLine struct {
+	text string
+	bold bool
+}
+
+// cellLines splits the cell content into the lines it is rendered with,
+// measuring the subject in the bold variant of the current font
+func (g *PDFGenerator) cellLines(cell tableCell) []cellLine {
+	if !cell.wrap {
+		return []cellLine{{text: cell.text}}
+	}
+
+	var lines []cellLine
+	if cell.subject != "" {
+		fontSize, _ := g.pdf.GetFontSize()
+		g.pdf.SetFont(fontName, "B", fontSize)
+		for _, line := range g.pdf.SplitText(cell.subject, cell.width) {
+			lines = append(lines, cellLine{text: line, bold: true})
+		}
+		g.pdf.SetFont(fontName, "", fontSize)
+	}
+	if cell.text != "" || len(lines) == 0 {
+		for _, line := range g.pdf.SplitText(cell.text, cell.width) {
+			lines = append(lines, cellLine{text: line})
+		}
+	}
+	return lines
+}
+
+// drawRow renders a table row whose height fits the tallest cell. Every cell
+// is filled with the current fill color and framed to the full row height,
+// with shorter cells centered vertically when configured. A row taller than
+// a page is split across pages, continuing every cell's lines below the top
+// margin of the next page.
+func (g *PDFGenerator) drawRow(cells []tableCell, lineHeight float64) {
+	lines := make([][]cellLine, len(cells))
+	maxLines := 1
+	for i, cell := range cells {
+		lines[i] = g.cellLines(cell)
+		if len(lines[i]) > maxLines {
+			maxLines = len(lines[i])
+		}
+	}
+	rowHeight := float64(maxLines) * lineHeight
+
+	// Start a new page when the row does not fit on the current one but
+	// would fit on an empty one
+	_, pageHeight := g.pdf.GetPageSize()
+	_, topMargin, _, bottomMargin := g.pdf.GetMargins()
+	pageBottom := pageHeight - bottomMargin
+	if g.pdf.GetY()+rowHeight > pageBottom && rowHeight <= pageBottom-topMargin {
+		g.pdf.AddPage()
+	}
+	if g.pdf.GetY()+rowHeight <= pageBottom {
+		g.drawRowLines(cells, lines, 0, maxLines, lineHeight, g.cellVAlign == config.CellVAlignMiddle)
+		return
+	}
+
+	for from := 0; from < maxLines; {
+		fit := int((pageBottom - g.pdf.GetY()) / lineHeight)
+		if fit < 1 {
+			g.pdf.AddPage()
+			continue
+		}
+		to := from + fit
+		if to > maxLines {
+			to = maxLines
+		}
+		// Centering is meaningless for a row spread over several pages
+		g.drawRowLines(cells, lines, from, to, lineHeight, false)
+		if from = to; from < maxLines {
+			g.pdf.AddPage()
+		}
+	}
+}
+
+// drawRowLines draws lines from up to to of every cell as one framed piece
+// of a row at the current position and moves the cursor below it
+func (g *PDFGenerator) drawRowLines(cells []tableCell, lines [][]cellLine, from, to int, lineHeight float64, middle bool) {
+	height := float64(to-from) * lineHeight
+	fontSize, _ := g.pdf.GetFontSize()
+	x, y := g.pdf.GetXY()
+	for i, cell := range cells {
+		g.pdf.Rect(x, y, cell.width, height, "FD")
+		if cell.link != "" {
+			g.pdf.LinkString(x, y, cell.width, height, cell.link)
+		}
+
+		var cellLines []cellLine
+		if from < len(lines[i]) {
+			cellLines = lines[i][from:]
+			if to < len(lines[i]) {
+				cellLines = lines[i][from:to]
+			}
+		}
+		top := y
+		if middle {
+			top += (height - float64(len(cellLines))*lineHeight) / 2
+		}
+		for k, line := range cellLines {
+			style := ""
+			if line.bold {
+				style = "B"
+			}
+			g.pdf.SetFont(fontName, style, fontSize)
+			g.pdf.SetXY(x, top+float64(k)*lineHeight)
+			g.cellText(cell.width, lineHeight, line.text, cell.align, style)
+			g.pdf.SetFont(fontName, "", fontSize)
+		}
+		x += cell.width
+	}
+
+	g.pdf.SetXY(g.leftMargin(), y+height)
+}
+
+// leftMargin returns the current left page margin
+func (g *PDFGenerator) leftMargin() float64 {
+	left, _, _, _ := g.pdf.GetMargins()
+	return left
+}
+
+// availableWidth returns the page width between the left and right margins
+func (g *PDFGenerator) availableWidth() float64 {
+	pageWidth, _ := g.pdf.GetPageSize()
+	left, _, right, _ := g.pdf.GetMargins()
+	return pageWidth - left - right
+}
+
+// fitText truncates text with an ellipsis so it fits within the given cell
+// width using the current font
+func (g *PDFGenerator) fitText(text string, width float64) string {
+	width -= 2 * g.pdf.GetCellMargin()
+	if g.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && g.pdf.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// autoFitScale returns the factor the commit table columns and font have to
+// be scaled by for the fixed-width columns and a minimal description column
+// to fit within available, or 1 when the table already fits
+func autoFitScale(fixed, available float64) float64 {
+	total := fixed + minDescColWidth
+	if total <= available {
+		return 1
+	}
+	return available / total
+}
+
+// shaColumnWidth returns the configured SHA column width, or the width for
+// the configured policy measured with the current font
+func (g *PDFGenerator) shaColumnWidth(table config.TableConfig, policy string, commits []*git.Commit) float64 {
+	if table.SHAColWidth > 0 {
+		return table.SHAColWidth
+	}
+	if policy != config.SHAPolicyAuto {
+		return defaultSHAColWidth
+	}
+
+	width := g.pdf.GetStringWidth("SHA")
+	for _, commit := range commits {
+		if w := g.pdf.GetStringWidth(commit.SHA); w > width {
+			width = w
+		}
+	}
+	return width + 2*g.pdf.GetCellMargin()
+}
+
+// shaCell builds the SHA cell for a commit according to the configured policy
+func (g *PDFGenerator) shaCell(policy string, sha string, width float64) tableCell {
+	switch policy {
+	case config.SHAPolicyWrap:
+		return tableCell{width: width, text: sha, align: "C", wrap: true}
+	case config.SHAPolicyTruncate:
+		return tableCell{width: width, text: g.fitText(sha, width), align: "C"}
+	default:
+		return tableCell{width: width, text: sha, align: "C"}
+	}
+}
+
+// clipLines limits text to maxLines wrapped lines at the given cell width,
+// ending the last kept line with an ellipsis. A limit of zero keeps all lines.
+func (g *PDFGenerator) clipLines(text string, width float64, maxLines int) string {
+	if maxLines <= 0 || text == "" {
+		return text
+	}
+
+	lines := g.pdf.SplitText(text, width)
+	if len(lines) <= maxLines {
+		return text
+	}
+
+	lines = lines[:maxLines]
+	lines[maxLines-1] = g.fitText(strings.TrimRight(lines[maxLines-1], " ")+"…", width)
+	return strings.Join(lines, "\n")
+}
+
+// hasPullRequestLinks reports whether the remote hosts pull requests the
+// report can link to and any commit refers to one
+func (d *ReportData) hasPullRequestLinks() bool {
+	if git.PullRequestURL(d.RemoteURL, 1) == "" {
+		return false
+	}
+	for _, commit := range d.Commits {
+		if commit.PullRequest > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pullRequestCell returns the "#123" cell linking to the commit's pull request
+func (d *ReportData) pullRequestCell(commit *git.Commit, width float64) tableCell {
+	if commit.PullRequest == 0 {
+		return tableCell{width: width, align: "C"}
+	}
+	return tableCell{
+		width: width,
+		text:  fmt.Sprintf("#%d", commit.PullRequest),
+		align: "C",
+		link:  git.PullRequestURL(d.RemoteURL, commit.PullRequest),
+	}
+}
+
+// linesHeading returns the heading of the line statistics column
+func (d *ReportData) linesHeading() string {
+	if d.Config.Stats.SplitLines {
+		return "Linie"
+	}
+	return "Netto"
+}
+
+// linesText returns the line statistics of a commit: the net change, or the
+// inserted and deleted lines as "+X/-Y" with stats.split_lines
+func (d *ReportData) linesText(commit *git.Commit) string {
+	if d.Config.Stats.SplitLines {
+		return "+" + d.formatNumber(commit.Insertions) + "/-" + d.formatNumber(commit.Deletions)
+	}
+	return d.formatSigned(commit.NetLines)
+}
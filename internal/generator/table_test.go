@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"git-report-generator/internal/config"
+)
+
+func TestSHAColumnPoliciesFitTheCell(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.SHA = commit.Hash
+
+	for _, policy := range []string{config.SHAPolicyAuto, config.SHAPolicyWrap, config.SHAPolicyTruncate} {
+		data := testReportData(commit)
+		data.Config.PDF.SHAColumnPolicy = policy
+
+		// Rendering leaves the generator with the table font set up
+		g := NewPDFGenerator()
+		if err := g.Write(data, &bytes.Buffer{}); err != nil {
+			t.Fatalf("%s: failed to render PDF: %v", policy, err)
+		}
+		g.pdf.SetFont(fontName, "", data.Config.PDF.FontSizes.Table)
+
+		width := g.shaColumnWidth(data.Config.PDF.Table, policy, data.Commits)
+		inner := width - 2*g.pdf.GetCellMargin()
+		var rendered []string
+		for _, line := range g.cellLines(g.shaCell(policy, commit.SHA, width)) {
+			if w := g.pdf.GetStringWidth(line.text); w > inner+0.01 {
+				t.Errorf("%s: line %q is %.2fmm wide, overflowing the %.2fmm cell", policy, line.text, w, inner)
+			}
+			rendered = append(rendered, line.text)
+		}
+
+		text := strings.Join(rendered, "")
+		switch policy {
+		case config.SHAPolicyTruncate:
+			if !strings.HasSuffix(text, "…") || !strings.HasPrefix(commit.SHA, strings.TrimSuffix(text, "…")) {
+				t.Errorf("%s: rendered %q, want a prefix of the SHA ending in an ellipsis", policy, text)
+			}
+		default:
+			if text != commit.SHA {
+				t.Errorf("%s: rendered %q, want the full SHA", policy, text)
+			}
+		}
+	}
+}
+
+func TestAutoFitScale(t *testing.T) {
+	if scale := autoFitScale(100, 170); scale != 1 {
+		t.Errorf("scale of a fitting table = %v, want 1", scale)
+	}
+	// 130mm of columns plus the 40mm minimal description just fit
+	if scale := autoFitScale(130, 170); scale != 1 {
+		t.Errorf("scale of an exactly fitting table = %v, want 1", scale)
+	}
+	if scale := autoFitScale(300, 170); scale != 0.5 {
+		t.Errorf("scale of a wide table = %v, want 0.5", scale)
+	}
+}
+
+func TestAutoFitTableScalesWideColumns(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.Ticket = "JIRA-123"
+	data := testReportData(commit)
+	data.Config.PDF.AutoFitTable = true
+	data.Config.PDF.Table = config.TableConfig{DateColWidth: 60, SHAColWidth: 60}
+	data.Config.CommitParsing.ShowTicketColumn = true
+	data.Config.Stats.WithStats = true
+	data.Config.Estimation.Enabled = true
+	items := pdfText(t, renderPDF(t, data))
+
+	m := data.Config.PDF
+	available := config.PageWidth - m.MarginLeft - m.MarginRight
+	fixed := 60 + 60 + config.TicketColWidth + config.NetColWidth + config.EffortColWidth
+	scale := available / (fixed + minDescColWidth)
+	if row := findText(t, items, "Add login form"); math.Abs(row.size-m.FontSizes.Table*scale) > 0.01 {
+		t.Errorf("table font size = %.2f, want %.2f scaled by %.2f", row.size, m.FontSizes.Table*scale, scale)
+	}
+
+}
+
+func TestConfiguredColumnWidthsMoveColumns(t *testing.T) {
+	const ptPerMM = 72 / 25.4
+	shaX := func(table config.TableConfig) float64 {
+		data := testReportData(testCommit(3, "Add login form"))
+		data.Config.PDF.Table = table
+		return findText(t, pdfText(t, renderPDF(t, data)), "33333333").x
+	}
+
+	defaultX := shaX(config.TableConfig{})
+	if got := shaX(config.TableConfig{DateColWidth: config.DefaultDateColWidth}); math.Abs(got-defaultX) > 0.01 {
+		t.Errorf("SHA at x %.2f with the default date width given, want %.2f", got, defaultX)
+	}
+	wideX := shaX(config.TableConfig{DateColWidth: config.DefaultDateColWidth + 20})
+	if shift := (wideX - defaultX) / ptPerMM; math.Abs(shift-20) > 0.01 {
+		t.Errorf("SHA moved by %.2fmm with a 20mm wider date column, want 20mm", shift)
+	}
+}
+
+func TestCellVAlignMiddleCentersShortCells(t *testing.T) {
+	commit := testCommit(3, "Tall row")
+	commit.Description = "line one\nline two\nline three\nline four"
+
+	positions := func(valign string) (date, subject, last pdfTextItem) {
+		data := testReportData(commit)
+		data.Config.PDF.CellVAlign = valign
+		items := pdfText(t, renderPDF(t, data))
+		return findText(t, items, "2024-01-03"), findText(t, items, "Tall row"), findText(t, items, "line four")
+	}
+
+	date, subject, _ := positions(config.CellVAlignTop)
+	if math.Abs(date.y-subject.y) > 0.01 {
+		t.Errorf("top: date at y %.2f, want level with the first description line at %.2f", date.y, subject.y)
+	}
+
+	date, subject, last := positions(config.CellVAlignMiddle)
+	if middle := (subject.y + last.y) / 2; math.Abs(date.y-middle) > 0.01 {
+		t.Errorf("middle: date at y %.2f, want centered between %.2f and %.2f", date.y, subject.y, last.y)
+	}
+}
+
+func TestPullRequestColumnLinks(t *testing.T) {
+	commit := testCommit(3, "Add login form")
+	commit.PullRequest = 123
+	data := testReportData(commit, testCommit(2, "Initial commit"))
+
+	if items := pdfText(t, renderPDF(t, data)); rowText(items, findText(t, items, "Add login form").y, "#") != "" {
+		t.Error("expected no PR column without a known remote")
+	}
+
+	data.RemoteURL = "git@github.com:owner/repo.git"
+	content := renderPDF(t, data)
+	items := pdfText(t, content)
+	if got := rowText(items, findText(t, items, "Add login form").y, "#"); got != "#123" {
+		t.Errorf("PR cell = %q, want #123", got)
+	}
+	if !bytes.Contains(content, []byte("(https://github.com/owner/repo/pull/123)")) {
+		t.Error("expected the PR cell to link to the pull request")
+	}
+}
+
+func TestSplitLinesColumn(t *testing.T) {
+	first := testCommit(3, "Add parser")
+	first.Insertions, first.Deletions, first.NetLines = 12, 3, 9
+	second := testCommit(2, "Trim docs")
+	second.Insertions, second.Deletions, second.NetLines = 3, 1, 2
+	data := testReportData(first, second)
+	data.Config.Stats.WithStats = true
+
+	text := pdfJoinedText(t, renderPDF(t, data))
+	for _, want := range []string{"Netto", "+9", "Dodane / usunięte linie: +15 / -4"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q with net lines:\n%s", want, text)
+		}
+	}
+
+	data.Config.Stats.SplitLines = true
+	text = pdfJoinedText(t, renderPDF(t, data))
+	for _, want := range []string{"Linie", "+12/-3", "+3/-1", "Dodane / usunięte linie: +15 / -4"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q with split lines:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Netto\n") {
+		t.Errorf("unexpected net lines heading with split lines:\n%s", text)
+	}
+}
+
+func TestRowTallerThanPageSplitsAcrossPages(t *testing.T) {
+	lines := make([]string, 150)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("body line %d", i+1)
+	}
+	long := testCommit(3, "Long body")
+	long.Description = strings.Join(lines, "\n")
+	content := renderPDF(t, testReportData(long, testCommit(2, "Short")))
+
+	streams := pdfPageStreams(t, content)
+	if len(streams) > 5 {
+		t.Fatalf("expected the 150-line row to flow over a few pages, got %d pages", len(streams))
+	}
+	text := pdfJoinedText(t, content)
+	for _, want := range []string{"body line 1\n", "body line 75\n", "body line 150\n", "Short"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the report", want)
+		}
+	}
+	// Every page the row continues on frames its piece of the cells
+	for i, stream := range streams[1 : len(streams)-1] {
+		if !bytes.Contains(stream, []byte(" re ")) {
+			t.Errorf("page %d has no cell borders", i+2)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RunMetrics describes a single report generation run
+type RunMetrics struct {
+	Format      string
+	Duration    time.Duration
+	Commits     int
+	OutputBytes int64
+}
+
+// MetricsCollector receives the metrics of a finished generation run, e.g. to
+// forward them to a monitoring system
+type MetricsCollector interface {
+	Collect(m RunMetrics) error
+}
+
+// MetricsFunc adapts a plain function to the MetricsCollector interface
+type MetricsFunc func(m RunMetrics) error
+
+// Collect calls f with the run metrics
+func (f MetricsFunc) Collect(m RunMetrics) error {
+	return f(m)
+}
+
+// PushgatewayCollector pushes the run metrics to a Prometheus Pushgateway
+// in the text exposition format, grouped under the given job name
+type PushgatewayCollector struct {
+	URL    string
+	Job    string
+	Client *http.Client
+}
+
+// Collect replaces the metrics of the job on the Pushgateway
+func (p PushgatewayCollector) Collect(m RunMetrics) error {
+	job := p.Job
+	if job == "" {
+		job = "git_report_generator"
+	}
+	endpoint := strings.TrimRight(p.URL, "/") + "/metrics/job/" + url.PathEscape(job)
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewBufferString(prometheusText(m)))
+	if err != nil {
+		return fmt.Errorf("failed to create pushgateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to push metrics: pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
+
+// prometheusText renders the run metrics in the Prometheus text format
+func prometheusText(m RunMetrics) string {
+	var b strings.Builder
+	metric := func(name, help, kind string, value string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s{format=%q} %s\n", name, help, name, kind, name, m.Format, value)
+	}
+	metric("git_report_generation_duration_seconds", "Time taken to generate the report.", "gauge",
+		fmt.Sprintf("%g", m.Duration.Seconds()))
+	metric("git_report_commits", "Number of commits in the report.", "gauge",
+		fmt.Sprintf("%d", m.Commits))
+	metric("git_report_output_bytes", "Total size of the written report files.", "gauge",
+		fmt.Sprintf("%d", m.OutputBytes))
+	return b.String()
+}
+
+// CountingWriter passes writes through to W and counts the written bytes
+type CountingWriter struct {
+	W io.Writer
+	N int64
+}
+
+// Write writes p to the underlying writer
+func (c *CountingWriter) Write(p []byte) (int, error) {
+	n, err := c.W.Write(p)
+	c.N += int64(n)
+	return n, err
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPushgatewayCollector(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(content)
+	}))
+	defer server.Close()
+
+	collector := PushgatewayCollector{URL: server.URL + "/", Job: "nightly"}
+	err := collector.Collect(RunMetrics{Format: "pdf", Duration: 1500 * time.Millisecond, Commits: 7, OutputBytes: 2048})
+	if err != nil {
+		t.Fatalf("failed to push metrics: %v", err)
+	}
+
+	if method != http.MethodPut || path != "/metrics/job/nightly" {
+		t.Errorf("expected a PUT to /metrics/job/nightly, got %s %s", method, path)
+	}
+	for _, want := range []string{
+		`git_report_generation_duration_seconds{format="pdf"} 1.5`,
+		`git_report_commits{format="pdf"} 7`,
+		`git_report_output_bytes{format="pdf"} 2048`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in the pushed metrics:\n%s", want, body)
+		}
+	}
+}
+
+func TestPushgatewayCollectorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := (PushgatewayCollector{URL: server.URL}).Collect(RunMetrics{}); err == nil {
+		t.Error("expected an error when the pushgateway rejects the metrics")
+	}
+}
+
+func TestCountingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	counter := &CountingWriter{W: &buf}
+	io.WriteString(counter, "hello ")
+	io.WriteString(counter, "world")
+	if counter.N != 11 || buf.String() != "hello world" {
+		t.Errorf("expected 11 bytes passed through, got %d and %q", counter.N, buf.String())
+	}
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+// PeriodTotals summarizes the commits of a report period
+type PeriodTotals struct {
+	From       time.Time
+	To         time.Time
+	Commits    int
+	Insertions int
+	Deletions  int
+}
+
+// Totals sums up the commits of the period between from and to
+func Totals(commits []*git.Commit, from, to time.Time) PeriodTotals {
+	totals := PeriodTotals{From: from, To: to, Commits: len(commits)}
+	for _, commit := range commits {
+		totals.Insertions += commit.Insertions
+		totals.Deletions += commit.Deletions
+	}
+	return totals
+}
+
+// PreviousPeriod returns the range of the same number of calendar days
+// immediately preceding the one between from and to
+func PreviousPeriod(from, to time.Time) (time.Time, time.Time) {
+	days := int(math.Round(calendarDay(to).Sub(calendarDay(from)).Hours()/24)) + 1
+	return from.AddDate(0, 0, -days), to.AddDate(0, 0, -days)
+}
+
+// formatChange describes the relative change from previous to current, e.g.
+// "+12%", or "n/d" when there is nothing to compare against
+func formatChange(current, previous int) string {
+	if previous == 0 {
+		if current == 0 {
+			return "0%"
+		}
+		return "n/d"
+	}
+	change := math.Round(float64(current-previous) / float64(previous) * 100)
+	if change > 0 {
+		return fmt.Sprintf("+%.0f%%", change)
+	}
+	return fmt.Sprintf("%.0f%%", change)
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"git-report-generator/internal/git"
+)
+
+func TestPreviousPeriod(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	prevFrom, prevTo := PreviousPeriod(from, to)
+	if want := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC); !prevFrom.Equal(want) {
+		t.Errorf("expected the previous period to start %v, got %v", want, prevFrom)
+	}
+	if want := time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC); !prevTo.Equal(want) {
+		t.Errorf("expected the previous period to end %v, got %v", want, prevTo)
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	tests := []struct {
+		current, previous int
+		want              string
+	}{
+		{112, 100, "+12%"},
+		{75, 100, "-25%"},
+		{10, 10, "0%"},
+		{0, 0, "0%"},
+		{5, 0, "n/d"},
+	}
+	for _, tt := range tests {
+		if got := formatChange(tt.current, tt.previous); got != tt.want {
+			t.Errorf("formatChange(%d, %d) = %q, want %q", tt.current, tt.previous, got, tt.want)
+		}
+	}
+}
+
+func TestCompareWithPreviousPeriod(t *testing.T) {
+	withLines := func(day int, insertions, deletions int) *git.Commit {
+		commit := testCommit(day, "change")
+		commit.Insertions, commit.Deletions = insertions, deletions
+		return commit
+	}
+	data := testReportData(withLines(3, 30, 10), withLines(4, 30, 5), withLines(5, 0, 0))
+
+	prior := []*git.Commit{withLines(3, 40, 10), withLines(4, 10, 10)}
+	for _, commit := range prior {
+		commit.Date = commit.Date.AddDate(0, -1, 0)
+	}
+	from, to := PreviousPeriod(data.DateFrom, data.DateTo)
+	previous := Totals(prior, from, to)
+	if previous.Commits != 2 || previous.Insertions != 50 || previous.Deletions != 20 {
+		t.Fatalf("unexpected previous totals: %+v", previous)
+	}
+	data.Previous = &previous
+
+	text := pdfJoinedText(t, renderPDF(t, data))
+	if !strings.Contains(text, "2023-12-01 - 2023-12-31, 2 commitów") {
+		t.Error("expected the previous period in the summary")
+	}
+	if want := "commity +50%, dodane linie +20%, usunięte linie -25%"; !strings.Contains(text, want) {
+		t.Errorf("expected %q in the summary", want)
+	}
+}
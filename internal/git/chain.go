@@ -0,0 +1,47 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// VerifyChain walks the parent links back from the commit tipHash and
+// returns the commits that cannot be reached this way, in their original
+// order. An empty result proves no commit was injected into the set.
+func (s *Service) VerifyChain(commits []*Commit, tipHash string) ([]*Commit, error) {
+	pending := make(map[plumbing.Hash]bool, len(commits))
+	for _, commit := range commits {
+		pending[plumbing.NewHash(commit.Hash)] = true
+	}
+
+	visited := make(map[plumbing.Hash]bool)
+	queue := []plumbing.Hash{plumbing.NewHash(tipHash)}
+	for len(queue) > 0 && len(pending) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+		if visited[hash] {
+			continue
+		}
+		visited[hash] = true
+		delete(pending, hash)
+
+		commit, err := s.repo.CommitObject(hash)
+		if err == plumbing.ErrObjectNotFound {
+			// Shallow history ends here
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+		}
+		queue = append(queue, commit.ParentHashes...)
+	}
+
+	var unreachable []*Commit
+	for _, commit := range commits {
+		if pending[plumbing.NewHash(commit.Hash)] {
+			unreachable = append(unreachable, commit)
+		}
+	}
+	return unreachable, nil
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyChain(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("first", day(2), map[string]string{"a.txt": "1"})
+	r.commit("second", day(3), map[string]string{"a.txt": "2"})
+	r.checkout("side", true)
+	side := r.commit("side", day(4), map[string]string{"b.txt": "1"})
+	r.checkout("master", false)
+	tip := r.commit("third", day(5), map[string]string{"a.txt": "3"})
+
+	service := r.service()
+	commits := getCommits(t, service, januaryQuery("master"))
+	if len(commits) != 3 {
+		t.Fatalf("expected the 3 commits of master, got %q", subjects(commits))
+	}
+
+	unreachable, err := service.VerifyChain(commits, tip.String())
+	if err != nil {
+		t.Fatalf("failed to verify chain: %v", err)
+	}
+	if len(unreachable) != 0 {
+		t.Errorf("expected every master commit to chain to the tip, got %q", subjects(unreachable))
+	}
+
+	injected := &Commit{Hash: side.String(), Message: "side"}
+	forged := &Commit{Hash: strings.Repeat("ab", 20), Message: "forged"}
+	unreachable, err = service.VerifyChain(append([]*Commit{forged}, append(commits, injected)...), tip.String())
+	if err != nil {
+		t.Fatalf("failed to verify chain: %v", err)
+	}
+	if got := subjects(unreachable); len(got) != 2 || got[0] != "forged" || got[1] != "side" {
+		t.Errorf("expected the forged and side branch commits to be unreachable, got %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// scpLikeURL matches remote addresses in the scp-like syntax, e.g. git@host:owner/repo.git
+var scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:`)
+
+// CloneOptions controls how a remote repository is cloned
+type CloneOptions struct {
+	// Number of additional attempts after a transient network failure
+	Retries int
+
+	// Delay before the first retry, doubled after every further failure
+	Backoff time.Duration
+
+	// Receives a line for every failed attempt that is retried, may be nil
+	Logf func(format string, args ...interface{})
+}
+
+// IsRemoteURL reports whether repo refers to a remote repository rather than
+// a local path
+func IsRemoteURL(repo string) bool {
+	return strings.Contains(repo, "://") || scpLikeURL.MatchString(repo)
+}
+
+// RemoteName returns the repository name of a remote URL, e.g. "repo" for
+// https://host/owner/repo.git
+func RemoteName(url string) string {
+	name := strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		return "repository"
+	}
+	return name
+}
+
+// Clone mirrors the remote repository at url into dir, so every remote branch
+// is available under its own name. Transient network failures are retried
+// with exponential backoff; authentication and other errors fail immediately.
+func Clone(url, dir string, opts CloneOptions) error {
+	return withRetries("clone", url, opts, func() error {
+		// A failed attempt may leave a partial clone behind
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to clean clone directory: %w", err)
+		}
+		_, err := git.PlainClone(dir, true, &git.CloneOptions{URL: url, Mirror: true})
+		return err
+	})
+}
+
+// Fetch updates a mirror clone previously created by Clone in dir from url,
+// retrying transient network failures like Clone. It fails when dir is a
+// clone of a different remote.
+func Fetch(url, dir string, opts CloneOptions) error {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open clone in %s: %w", dir, err)
+	}
+
+	remote, err := repo.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return fmt.Errorf("failed to get remote of clone in %s: %w", dir, err)
+	}
+	if urls := remote.Config().URLs; len(urls) == 0 || urls[0] != url {
+		return fmt.Errorf("clone in %s is not a clone of %s", dir, url)
+	}
+
+	return withRetries("fetch", url, opts, func() error {
+		err := remote.Fetch(&git.FetchOptions{
+			RefSpecs: []config.RefSpec{"+refs/*:refs/*"},
+			Force:    true,
+		})
+		if err == git.NoErrAlreadyUpToDate {
+			return nil
+		}
+		return err
+	})
+}
+
+// withRetries runs the clone or fetch described by action until it
+// succeeds, fails with an error that is not transient or the retries are
+// used up
+func withRetries(action, url string, opts CloneOptions, run func() error) error {
+	delay := opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil {
+			return nil
+		}
+		if attempt > opts.Retries || !isTransient(err) {
+			return fmt.Errorf("failed to %s %s: %w", action, url, err)
+		}
+
+		if opts.Logf != nil {
+			opts.Logf("%s attempt %d of %d failed: %v (retrying in %s)", strings.ToUpper(action[:1])+action[1:], attempt, opts.Retries+1, err, delay)
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isTransient reports whether a clone failure is likely caused by a flaky
+// network rather than by the remote rejecting the request
+func isTransient(err error) bool {
+	for _, permanent := range []error{
+		transport.ErrAuthenticationRequired,
+		transport.ErrAuthorizationFailed,
+		transport.ErrInvalidAuthMethod,
+		transport.ErrRepositoryNotFound,
+		transport.ErrEmptyRemoteRepository,
+	} {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+
+	var httpErr *githttp.Err
+	if errors.As(err, &httpErr) {
+		return httpErr.Response != nil && httpErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
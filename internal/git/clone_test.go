@@ -0,0 +1,127 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+func TestWithRetriesTransientFailures(t *testing.T) {
+	var logged []string
+	opts := CloneOptions{
+		Retries: 3,
+		Logf: func(format string, args ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		},
+	}
+
+	attempts := 0
+	err := withRetries("clone", "https://example.com/repo.git", opts, func() error {
+		attempts++
+		if attempts <= 2 {
+			return fmt.Errorf("read: %w", syscall.ECONNRESET)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if len(logged) != 2 {
+		t.Errorf("expected a line for each failed attempt, got %q", logged)
+	}
+}
+
+func TestWithRetriesGivesUp(t *testing.T) {
+	attempts := 0
+	err := withRetries("clone", "https://example.com/repo.git", CloneOptions{Retries: 2}, func() error {
+		attempts++
+		return syscall.ECONNREFUSED
+	})
+	if err == nil {
+		t.Fatal("expected an error once the retries are used up")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetriesAuthFailure(t *testing.T) {
+	attempts := 0
+	err := withRetries("clone", "https://example.com/repo.git", CloneOptions{Retries: 3}, func() error {
+		attempts++
+		return transport.ErrAuthenticationRequired
+	})
+	if !errors.Is(err, transport.ErrAuthenticationRequired) {
+		t.Fatalf("expected the authentication error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retry of an authentication failure, got %d attempts", attempts)
+	}
+}
+
+func TestCloneLocalRemote(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("first", day(2), map[string]string{"a.txt": "a"})
+
+	dir := filepath.Join(t.TempDir(), "clone")
+	if err := Clone("file://"+r.dir, dir, CloneOptions{Retries: 1}); err != nil {
+		t.Fatalf("failed to clone: %v", err)
+	}
+
+	service, err := NewService(dir)
+	if err != nil {
+		t.Fatalf("failed to open clone: %v", err)
+	}
+	if got := subjects(getCommits(t, service, januaryQuery("master"))); len(got) != 1 || got[0] != "first" {
+		t.Errorf("expected the cloned commit, got %q", got)
+	}
+}
+
+func TestFetchUpdatesClone(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("first", day(2), map[string]string{"a.txt": "a"})
+	url := "file://" + r.dir
+	dir := filepath.Join(t.TempDir(), "clone")
+	if err := Clone(url, dir, CloneOptions{}); err != nil {
+		t.Fatalf("failed to clone: %v", err)
+	}
+
+	r.commit("second", day(3), map[string]string{"a.txt": "b"})
+	if err := Fetch(url, dir, CloneOptions{}); err != nil {
+		t.Fatalf("failed to fetch: %v", err)
+	}
+	service, err := NewService(dir)
+	if err != nil {
+		t.Fatalf("failed to open clone: %v", err)
+	}
+	if got := subjects(getCommits(t, service, januaryQuery("master"))); len(got) != 2 || got[0] != "second" {
+		t.Errorf("expected the fetched commit, got %q", got)
+	}
+
+	// Fetching again with nothing new is not an error
+	if err := Fetch(url, dir, CloneOptions{}); err != nil {
+		t.Errorf("failed to fetch an up-to-date clone: %v", err)
+	}
+}
+
+func TestFetchRejectsCloneOfOtherRemote(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("first", day(2), nil)
+	dir := filepath.Join(t.TempDir(), "clone")
+	if err := Clone("file://"+r.dir, dir, CloneOptions{}); err != nil {
+		t.Fatalf("failed to clone: %v", err)
+	}
+
+	err := Fetch("file:///elsewhere/repo.git", dir, CloneOptions{})
+	if err == nil || !strings.Contains(err.Error(), "is not a clone of") {
+		t.Errorf("expected a different remote to be rejected, got %v", err)
+	}
+}
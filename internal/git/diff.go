@@ -0,0 +1,162 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitTrees returns the trees of the commit's first parent and of the commit
+// itself. Root commits get an empty parent tree.
+func commitTrees(c *object.Commit) (parentTree, tree *object.Tree, err error) {
+	tree, err = c.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tree for commit %s: %w", c.Hash, err)
+	}
+
+	parentTree = &object.Tree{}
+	if c.NumParents() != 0 {
+		parent, err := c.Parents().Next()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get parent of commit %s: %w", c.Hash, err)
+		}
+
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get parent tree for commit %s: %w", c.Hash, err)
+		}
+	}
+
+	return parentTree, tree, nil
+}
+
+// statTrees returns the trees statistics are computed between: the fixed base
+// tree when set, otherwise the commit's first parent, and the commit itself
+func statTrees(c *object.Commit, base *object.Tree) (fromTree, tree *object.Tree, err error) {
+	if base == nil {
+		return commitTrees(c)
+	}
+
+	tree, err = c.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tree for commit %s: %w", c.Hash, err)
+	}
+
+	return base, tree, nil
+}
+
+// commitPatch returns the patch between the commit and its first parent.
+// Root commits are diffed against an empty tree.
+func commitPatch(c *object.Commit) (*object.Patch, error) {
+	return statPatch(c, nil)
+}
+
+// statPatch returns the patch between the commit and the base tree, or its
+// first parent when base is nil
+func statPatch(c *object.Commit, base *object.Tree) (*object.Patch, error) {
+	parentTree, tree, err := statTrees(c, base)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := parentTree.Patch(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute patch for commit %s: %w", c.Hash, err)
+	}
+
+	return patch, nil
+}
+
+// changedLines returns the total number of added and deleted lines in a patch
+func changedLines(patch *object.Patch) int {
+	total := 0
+	for _, stat := range patch.Stats() {
+		total += stat.Addition + stat.Deletion
+	}
+	return total
+}
+
+// attachDiff fills in the commit's diff text, with contextLines unchanged
+// lines around each change, and its changed-line count. The diff text is
+// omitted when the commit changes more than maxLines lines.
+func attachDiff(commit *Commit, c *object.Commit, maxLines, contextLines int) error {
+	patch, err := commitPatch(c)
+	if err != nil {
+		return err
+	}
+
+	commit.DiffLines = changedLines(patch)
+	if commit.DiffLines <= maxLines {
+		var text strings.Builder
+		if err := diff.NewUnifiedEncoder(&text, contextLines).Encode(patch); err != nil {
+			return fmt.Errorf("failed to encode diff of commit %s: %w", c.Hash, err)
+		}
+		commit.Diff = text.String()
+	}
+
+	return nil
+}
+
+// attachByteStats fills in the bytes added and removed by the commit, computed
+// from the size difference of each changed blob. Unlike line counts this also
+// reflects changes to binary files. When base is set the commit is compared
+// against it instead of its parent.
+func attachByteStats(commit *Commit, c *object.Commit, base *object.Tree) error {
+	parentTree, tree, err := statTrees(c, base)
+	if err != nil {
+		return err
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.Background(), parentTree, tree, &object.DiffTreeOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to diff commit %s: %w", c.Hash, err)
+	}
+
+	for _, change := range changes {
+		from, to, err := change.Files()
+		if err != nil {
+			return fmt.Errorf("failed to get files of commit %s: %w", c.Hash, err)
+		}
+
+		var fromSize, toSize int64
+		if from != nil {
+			fromSize = from.Size
+		}
+		if to != nil {
+			toSize = to.Size
+		}
+
+		if toSize > fromSize {
+			commit.BytesAdded += toSize - fromSize
+		} else {
+			commit.BytesRemoved += fromSize - toSize
+		}
+	}
+
+	return nil
+}
+
+// attachLineStats fills in the lines inserted and deleted by the commit, or
+// between base and the commit when base is set
+func attachLineStats(commit *Commit, c *object.Commit, base *object.Tree) error {
+	patch, err := statPatch(c, base)
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range patch.Stats() {
+		commit.Insertions += stat.Addition
+		commit.Deletions += stat.Deletion
+		commit.FileStats = append(commit.FileStats, FileStat{
+			Path:       stat.Name,
+			Insertions: stat.Addition,
+			Deletions:  stat.Deletion,
+		})
+	}
+	commit.NetLines = commit.Insertions - commit.Deletions
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIncludeDiffsEmbedsOnlySmallDiffs(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Initial commit", day(1), map[string]string{"small.txt": "one\n"})
+	r.commit("Tiny change", day(2), map[string]string{"small.txt": "two\n"})
+	r.commit("Large change", day(3), map[string]string{"large.txt": strings.Repeat("line\n", 50)})
+
+	query := januaryQuery("master")
+	query.IncludeDiffs = true
+	query.MaxDiffLines = 10
+	commits := getCommits(t, r.service(), query)
+	if len(commits) != 3 {
+		t.Fatalf("got %d commits, want 3", len(commits))
+	}
+
+	large, tiny := commits[0], commits[1]
+	if !strings.Contains(tiny.Diff, "-one\n+two\n") {
+		t.Errorf("tiny commit diff = %q, want the changed line", tiny.Diff)
+	}
+	if tiny.DiffLines != 2 {
+		t.Errorf("tiny commit diff lines = %d, want 2", tiny.DiffLines)
+	}
+	if large.Diff != "" {
+		t.Errorf("large commit diff embedded: %q", large.Diff)
+	}
+	if large.DiffLines != 50 {
+		t.Errorf("large commit diff lines = %d, want 50", large.DiffLines)
+	}
+}
+
+func TestDiffContextLines(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Initial commit", day(1), map[string]string{"file.txt": "a\nb\nc\nd\ne\nf\ng\n"})
+	r.commit("Change middle", day(2), map[string]string{"file.txt": "a\nb\nc\nX\ne\nf\ng\n"})
+
+	diffs := map[int]string{}
+	for _, context := range []int{0, 3} {
+		query := januaryQuery("master")
+		query.IncludeDiffs = true
+		query.MaxDiffLines = 10
+		query.DiffContext = context
+		diffs[context] = getCommits(t, r.service(), query)[0].Diff
+	}
+
+	if !strings.HasSuffix(diffs[0], "\n-d\n+X\n") || strings.Contains(diffs[0], "\n c\n") {
+		t.Errorf("diff without context = %q, want only the changed line", diffs[0])
+	}
+	if !strings.Contains(diffs[3], "@@ -1,7 +1,7 @@\n a\n b\n c\n-d\n+X\n e\n f\n g\n") {
+		t.Errorf("diff with 3 context lines = %q, want the surrounding lines", diffs[3])
+	}
+	if len(diffs[0]) >= len(diffs[3]) {
+		t.Errorf("diff without context (%d bytes) is not tighter than with 3 lines (%d bytes)", len(diffs[0]), len(diffs[3]))
+	}
+}
+
+func TestByteStatsOnBinaryFile(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Add image", day(2), map[string]string{"logo.png": "\x89PNG\x00" + strings.Repeat("\x00\x01", 50)})
+	r.commit("Shrink image", day(3), map[string]string{"logo.png": "\x89PNG\x00" + strings.Repeat("\x00\x01", 20)})
+
+	query := januaryQuery("master")
+	query.ByteStats = true
+	query.WithStats = true
+	commits := getCommits(t, r.service(), query)
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+
+	shrink, add := commits[0], commits[1]
+	if add.BytesAdded != 105 || add.BytesRemoved != 0 {
+		t.Errorf("added image bytes = +%d -%d, want +105 -0", add.BytesAdded, add.BytesRemoved)
+	}
+	if shrink.BytesAdded != 0 || shrink.BytesRemoved != 60 {
+		t.Errorf("shrunk image bytes = +%d -%d, want +0 -60", shrink.BytesAdded, shrink.BytesRemoved)
+	}
+	// Binary files have no lines to count
+	if shrink.Insertions != 0 || shrink.Deletions != 0 {
+		t.Errorf("shrunk image lines = +%d -%d, want +0 -0", shrink.Insertions, shrink.Deletions)
+	}
+}
+
+func TestNetLinesOfDeletionHeavyCommit(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Add module", day(2), map[string]string{"module.go": strings.Repeat("code\n", 50)})
+	r.commit("Trim module", day(3), map[string]string{"module.go": strings.Repeat("code\n", 6) + "new\nnew\n"})
+
+	query := januaryQuery("master")
+	query.WithStats = true
+	trim := getCommits(t, r.service(), query)[0]
+
+	if trim.Insertions != 2 || trim.Deletions != 44 {
+		t.Errorf("lines = +%d -%d, want +2 -44", trim.Insertions, trim.Deletions)
+	}
+	if trim.NetLines != -42 {
+		t.Errorf("net lines = %d, want -42", trim.NetLines)
+	}
+}
+
+func TestStatAgainstFixedBase(t *testing.T) {
+	r := newTestRepo(t)
+	base := r.commit("Base", day(1), map[string]string{"a.txt": strings.Repeat("a\n", 10)})
+	r.commit("Add five", day(2), map[string]string{"a.txt": strings.Repeat("a\n", 15)})
+	r.commit("Add three", day(3), map[string]string{"a.txt": strings.Repeat("a\n", 18)})
+	service := r.service()
+
+	for _, tc := range []struct {
+		against string
+		want    []int
+	}{
+		{"", []int{3, 5, 10}},
+		{base.String(), []int{8, 5, 0}},
+	} {
+		query := januaryQuery("master")
+		query.WithStats = true
+		query.StatAgainst = tc.against
+		var got []int
+		for _, commit := range getCommits(t, service, query) {
+			got = append(got, commit.Insertions)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("stat against %q: insertions = %v, want %v", tc.against, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// testAuthor is the author of test commits unless another one is given
+var testAuthor = object.Signature{Name: "Jan Kowalski", Email: "jan@example.com"}
+
+// testRepo is a temporary repository histories are built in
+type testRepo struct {
+	t        *testing.T
+	dir      string
+	repo     *git.Repository
+	worktree *git.Worktree
+}
+
+// newTestRepo initializes an empty repository in a temporary directory
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	return &testRepo{t: t, dir: dir, repo: repo, worktree: worktree}
+}
+
+// day returns 10:00 UTC on the given day of January 2024
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 10, 0, 0, 0, time.UTC)
+}
+
+// commit writes and stages the files and commits them as testAuthor
+func (r *testRepo) commit(message string, when time.Time, files map[string]string) plumbing.Hash {
+	r.t.Helper()
+	return r.commitAs(testAuthor, message, when, files)
+}
+
+// commitAs writes and stages the files and commits them as author
+func (r *testRepo) commitAs(author object.Signature, message string, when time.Time, files map[string]string) plumbing.Hash {
+	r.t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			r.t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			r.t.Fatalf("failed to write %s: %v", name, err)
+		}
+		if _, err := r.worktree.Add(name); err != nil {
+			r.t.Fatalf("failed to stage %s: %v", name, err)
+		}
+	}
+
+	author.When = when
+	hash, err := r.worktree.Commit(message, &git.CommitOptions{
+		Author:            &author,
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		r.t.Fatalf("failed to commit %q: %v", message, err)
+	}
+	return hash
+}
+
+// checkout switches to the branch, creating it at HEAD when create is set
+func (r *testRepo) checkout(branch string, create bool) {
+	r.t.Helper()
+	err := r.worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+		Create: create,
+	})
+	if err != nil {
+		r.t.Fatalf("failed to check out %s: %v", branch, err)
+	}
+}
+
+// service opens the repository with NewService
+func (r *testRepo) service() *Service {
+	r.t.Helper()
+	service, err := NewService(r.dir)
+	if err != nil {
+		r.t.Fatalf("failed to open repository: %v", err)
+	}
+	return service
+}
+
+// januaryQuery returns a query for the commits of testAuthor on the branch
+// in January 2024
+func januaryQuery(branch string) CommitQuery {
+	return CommitQuery{
+		From:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		AuthorEmails: []string{testAuthor.Email},
+		Branch:       branch,
+	}
+}
+
+// getCommits runs the query and fails the test on errors
+func getCommits(t *testing.T, service *Service, query CommitQuery) []*Commit {
+	t.Helper()
+	commits, err := service.GetCommits(query)
+	if err != nil {
+		t.Fatalf("failed to get commits: %v", err)
+	}
+	return commits
+}
+
+// subjects returns the commit subjects in order
+func subjects(commits []*Commit) []string {
+	messages := make([]string, len(commits))
+	for i, commit := range commits {
+		messages[i] = commit.Message
+	}
+	return messages
+}
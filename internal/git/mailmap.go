@@ -0,0 +1,142 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// mailmapEntry maps a commit identity to its canonical name and email.
+// An empty commit name matches any name used with the commit email.
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	commitName  string
+	commitEmail string
+}
+
+// mailmap canonicalizes author identities as described by a .mailmap file
+type mailmap struct {
+	entries []mailmapEntry
+}
+
+// loadMailmap reads the repository's .mailmap from the worktree root, falling
+// back to the one committed at HEAD. A missing file yields an empty mailmap.
+func (s *Service) loadMailmap() (*mailmap, error) {
+	file, err := os.Open(filepath.Join(s.repoPath, ".mailmap"))
+	if err == nil {
+		defer file.Close()
+		return parseMailmap(file)
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to open .mailmap: %w", err)
+	}
+
+	head, err := s.repo.Head()
+	if err != nil {
+		return &mailmap{}, nil
+	}
+	commit, err := s.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	blob, err := commit.File(".mailmap")
+	if err != nil {
+		return &mailmap{}, nil
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .mailmap: %w", err)
+	}
+	defer reader.Close()
+
+	return parseMailmap(reader)
+}
+
+// parseMailmap parses mailmap lines in any of the forms supported by git:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func parseMailmap(r io.Reader) (*mailmap, error) {
+	m := &mailmap{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		var names, emails []string
+		for {
+			open := strings.Index(line, "<")
+			if open < 0 {
+				break
+			}
+			end := strings.Index(line[open:], ">")
+			if end < 0 {
+				break
+			}
+			names = append(names, strings.TrimSpace(line[:open]))
+			emails = append(emails, strings.TrimSpace(line[open+1:open+end]))
+			line = line[open+end+1:]
+		}
+
+		switch len(emails) {
+		case 1:
+			m.entries = append(m.entries, mailmapEntry{properName: names[0], commitEmail: emails[0]})
+		case 2:
+			m.entries = append(m.entries, mailmapEntry{
+				properName:  names[0],
+				properEmail: emails[0],
+				commitName:  names[1],
+				commitEmail: emails[1],
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read .mailmap: %w", err)
+	}
+
+	return m, nil
+}
+
+// resolve returns the canonical identity for the given commit signature.
+// Entries naming both the commit name and email take precedence over entries
+// matching the email alone.
+func (m *mailmap) resolve(sig object.Signature) object.Signature {
+	var match *mailmapEntry
+	for i := range m.entries {
+		entry := &m.entries[i]
+		if !strings.EqualFold(entry.commitEmail, sig.Email) {
+			continue
+		}
+		if entry.commitName != "" {
+			if strings.EqualFold(entry.commitName, sig.Name) {
+				match = entry
+				break
+			}
+			continue
+		}
+		if match == nil {
+			match = entry
+		}
+	}
+
+	if match != nil {
+		if match.properName != "" {
+			sig.Name = match.properName
+		}
+		if match.properEmail != "" {
+			sig.Email = match.properEmail
+		}
+	}
+	return sig
+}
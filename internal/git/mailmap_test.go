@@ -0,0 +1,60 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestMailmapResolvesAliasToCanonicalIdentity(t *testing.T) {
+	alias := object.Signature{Name: "jkowalski", Email: "jan@old-laptop.local"}
+	r := newTestRepo(t)
+	r.commit("Canonical identity", day(2), map[string]string{
+		".mailmap": "Jan Kowalski <jan@example.com> <jan@old-laptop.local>\n",
+	})
+	r.commitAs(alias, "Alias identity", day(3), map[string]string{"a.txt": "1"})
+	service := r.service()
+
+	commits := getCommits(t, service, januaryQuery("master"))
+	if got, want := subjects(commits), []string{"Alias identity", "Canonical identity"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("commits = %q, want %q", got, want)
+	}
+	if commits[0].Author != testAuthor.Name || commits[0].AuthorEmail != testAuthor.Email {
+		t.Errorf("alias author = %s <%s>, want %s <%s>",
+			commits[0].Author, commits[0].AuthorEmail, testAuthor.Name, testAuthor.Email)
+	}
+
+	query := januaryQuery("master")
+	query.IgnoreMailmap = true
+	if got, want := subjects(getCommits(t, service, query)), []string{"Canonical identity"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("without mailmap commits = %q, want %q", got, want)
+	}
+}
+
+func TestParseMailmapForms(t *testing.T) {
+	m, err := parseMailmap(strings.NewReader(`# comment
+<jan@example.com> <jan@old.local>
+Anna Nowak <anna@example.com>
+Jan Kowalski <jan@example.com> Old Name <shared@example.com>
+`))
+	if err != nil {
+		t.Fatalf("parseMailmap failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		in   object.Signature
+		want object.Signature
+	}{
+		{object.Signature{Name: "jk", Email: "JAN@old.local"}, object.Signature{Name: "jk", Email: "jan@example.com"}},
+		{object.Signature{Name: "anna", Email: "anna@example.com"}, object.Signature{Name: "Anna Nowak", Email: "anna@example.com"}},
+		{object.Signature{Name: "Old Name", Email: "shared@example.com"}, object.Signature{Name: "Jan Kowalski", Email: "jan@example.com"}},
+		{object.Signature{Name: "Other", Email: "shared@example.com"}, object.Signature{Name: "Other", Email: "shared@example.com"}},
+	} {
+		if got := m.resolve(tc.in); got.Name != tc.want.Name || got.Email != tc.want.Email {
+			t.Errorf("resolve(%s <%s>) = %s <%s>, want %s <%s>",
+				tc.in.Name, tc.in.Email, got.Name, got.Email, tc.want.Name, tc.want.Email)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// notesRef is the reference git notes are stored under by default
+const notesRef = "refs/notes/commits"
+
+// loadNotes returns the git notes attached to commits, keyed by commit hash.
+// Repositories without notes yield an empty map.
+func (s *Service) loadNotes() (map[plumbing.Hash]string, error) {
+	notes := make(map[plumbing.Hash]string)
+
+	ref, err := s.repo.Reference(plumbing.ReferenceName(notesRef), true)
+	if err == plumbing.ErrReferenceNotFound {
+		return notes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes reference: %w", err)
+	}
+
+	commit, err := s.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes commit: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes tree: %w", err)
+	}
+
+	// Note files are named after the annotated commit, possibly split into
+	// fan-out directories such as "ab/cdef..."
+	err = tree.Files().ForEach(func(f *object.File) error {
+		name := strings.ReplaceAll(f.Name, "/", "")
+		if len(name) != 40 {
+			return nil
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to read note %s: %w", f.Name, err)
+		}
+		notes[plumbing.NewHash(name)] = strings.TrimSpace(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// addNote attaches a git note to the commit under refs/notes/commits, as
+// "git notes add" does for a repository without notes
+func (r *testRepo) addNote(commit plumbing.Hash, note string) {
+	r.t.Helper()
+	storer := r.repo.Storer
+
+	blob := storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	writer, err := blob.Writer()
+	if err != nil {
+		r.t.Fatalf("failed to write note blob: %v", err)
+	}
+	writer.Write([]byte(note + "\n"))
+	writer.Close()
+	blobHash, err := storer.SetEncodedObject(blob)
+	if err != nil {
+		r.t.Fatalf("failed to store note blob: %v", err)
+	}
+
+	tree := &object.Tree{Entries: []object.TreeEntry{{Name: commit.String(), Mode: filemode.Regular, Hash: blobHash}}}
+	treeObject := storer.NewEncodedObject()
+	if err := tree.Encode(treeObject); err != nil {
+		r.t.Fatalf("failed to encode notes tree: %v", err)
+	}
+	treeHash, err := storer.SetEncodedObject(treeObject)
+	if err != nil {
+		r.t.Fatalf("failed to store notes tree: %v", err)
+	}
+
+	notesCommit := &object.Commit{Author: testAuthor, Committer: testAuthor, Message: "Notes added by 'git notes add'", TreeHash: treeHash}
+	commitObject := storer.NewEncodedObject()
+	if err := notesCommit.Encode(commitObject); err != nil {
+		r.t.Fatalf("failed to encode notes commit: %v", err)
+	}
+	commitHash, err := storer.SetEncodedObject(commitObject)
+	if err != nil {
+		r.t.Fatalf("failed to store notes commit: %v", err)
+	}
+
+	if err := storer.SetReference(plumbing.NewHashReference(notesRef, commitHash)); err != nil {
+		r.t.Fatalf("failed to set notes reference: %v", err)
+	}
+}
+
+func TestIncludeNotesAttachesNote(t *testing.T) {
+	r := newTestRepo(t)
+	annotated := r.commit("Annotated", day(2), map[string]string{"a.txt": "1"})
+	r.commit("Plain", day(3), map[string]string{"a.txt": "2"})
+	r.addNote(annotated, "Reviewed by QA")
+	service := r.service()
+
+	query := januaryQuery("master")
+	query.IncludeNotes = true
+	commits := getCommits(t, service, query)
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	if commits[1].Note != "Reviewed by QA" {
+		t.Errorf("note = %q, want \"Reviewed by QA\"", commits[1].Note)
+	}
+	if commits[0].Note != "" {
+		t.Errorf("commit without a note got %q", commits[0].Note)
+	}
+
+	// Notes are only read when requested
+	for _, commit := range getCommits(t, service, januaryQuery("master")) {
+		if commit.Note != "" {
+			t.Errorf("note attached without IncludeNotes: %q", commit.Note)
+		}
+	}
+}
+
+func TestIncludeNotesWithoutNotesRef(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Plain", day(2), map[string]string{"a.txt": "1"})
+
+	query := januaryQuery("master")
+	query.IncludeNotes = true
+	if commits := getCommits(t, r.service(), query); len(commits) != 1 || commits[0].Note != "" {
+		t.Errorf("commits = %v, want one commit without a note", commits)
+	}
+}
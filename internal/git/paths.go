@@ -0,0 +1,75 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// pathFilter matches commits that touch any of a set of paths. When renames
+// are followed, the previous name of a renamed tracked file is added to the
+// set, so older commits are matched under the file's former name.
+type pathFilter struct {
+	paths         []string
+	followRenames bool
+}
+
+// newPathFilter creates a filter for the given repository-relative paths
+func newPathFilter(paths []string, followRenames bool) *pathFilter {
+	cleaned := make([]string, 0, len(paths))
+	for _, p := range paths {
+		cleaned = append(cleaned, path.Clean(strings.TrimPrefix(p, "./")))
+	}
+
+	return &pathFilter{
+		paths:         cleaned,
+		followRenames: followRenames,
+	}
+}
+
+// covers reports whether the file path equals or lies under a tracked path
+func (f *pathFilter) covers(filePath string) bool {
+	for _, p := range f.paths {
+		if p == "." || filePath == p || strings.HasPrefix(filePath, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// touches reports whether the commit changes a tracked path. Commits must be
+// visited from newest to oldest for rename tracking to work.
+func (f *pathFilter) touches(c *object.Commit) (bool, error) {
+	parentTree, tree, err := commitTrees(c)
+	if err != nil {
+		return false, err
+	}
+
+	opts := &object.DiffTreeOptions{}
+	if f.followRenames {
+		opts = object.DefaultDiffTreeOptions
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.Background(), parentTree, tree, opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to diff commit %s: %w", c.Hash, err)
+	}
+
+	touched := false
+	var renamedFrom []string
+	for _, change := range changes {
+		from, to := change.From.Name, change.To.Name
+		if f.covers(to) || f.covers(from) {
+			touched = true
+		}
+		if f.followRenames && from != "" && to != "" && from != to && f.covers(to) && !f.covers(from) {
+			renamedFrom = append(renamedFrom, from)
+		}
+	}
+	f.paths = append(f.paths, renamedFrom...)
+
+	return touched, nil
+}
@@ -0,0 +1,42 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFollowRenamesMatchesFormerName(t *testing.T) {
+	content := strings.Repeat("a line that stays the same\n", 20)
+	r := newTestRepo(t)
+	r.commit("Add old name", day(2), map[string]string{"old.txt": content})
+	r.commit("Edit under old name", day(3), map[string]string{"old.txt": content + "one\n"})
+	r.commit("Unrelated change", day(4), map[string]string{"other.txt": "x"})
+
+	if err := os.Rename(filepath.Join(r.dir, "old.txt"), filepath.Join(r.dir, "new.txt")); err != nil {
+		t.Fatalf("failed to rename file: %v", err)
+	}
+	if _, err := r.worktree.Remove("old.txt"); err != nil {
+		t.Fatalf("failed to stage removal: %v", err)
+	}
+	r.commit("Rename to new name", day(5), map[string]string{"new.txt": content + "one\n"})
+	r.commit("Edit under new name", day(6), map[string]string{"new.txt": content + "one\ntwo\n"})
+	service := r.service()
+
+	for _, tc := range []struct {
+		follow bool
+		want   []string
+	}{
+		{false, []string{"Edit under new name", "Rename to new name"}},
+		{true, []string{"Edit under new name", "Rename to new name", "Edit under old name", "Add old name"}},
+	} {
+		query := januaryQuery("master")
+		query.Paths = []string{"new.txt"}
+		query.FollowRenames = tc.follow
+		if got := subjects(getCommits(t, service, query)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("follow renames %v: commits = %q, want %q", tc.follow, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pull/merge request references GitHub and GitLab put into merge and squash
+// commit messages
+var (
+	// "Merge pull request #123 from owner/branch" subjects of GitHub merges,
+	// and "(#123)" or "(!123)" suffixes of GitHub and GitLab squash subjects
+	pullRequestSubject = regexp.MustCompile(`^Merge pull request #(\d+)|\((?:#|!)(\d+)\)\s*$`)
+
+	// "See merge request group/project!123" lines in GitLab merge bodies
+	mergeRequestBody = regexp.MustCompile(`(?m)^See merge request \S+!(\d+)\s*$`)
+)
+
+// pullRequestNumber returns the number of the pull or merge request the
+// commit message refers to, or 0 when there is none
+func pullRequestNumber(fullMessage string) int {
+	message := strings.TrimSpace(fullMessage)
+	subject, _, _ := strings.Cut(message, "\n")
+
+	match := pullRequestSubject.FindStringSubmatch(subject)
+	if match == nil {
+		match = mergeRequestBody.FindStringSubmatch(message)
+	}
+	if match == nil {
+		return 0
+	}
+	for _, group := range match[1:] {
+		if number, err := strconv.Atoi(group); err == nil {
+			return number
+		}
+	}
+	return 0
+}
+
+// remoteWebURL matches the host and project path of https, ssh and scp-like
+// remote URLs
+var remoteWebURL = regexp.MustCompile(`^(?:[a-z+]+://)?(?:[^@/]+@)?([^/:]+)(?::\d+)?[:/](.+?)(?:\.git)?/?$`)
+
+// PullRequestURL returns the web address of the pull request on GitHub or
+// merge request on GitLab the remote URL points to, or an empty string for
+// other hosts
+func PullRequestURL(remoteURL string, number int) string {
+	match := remoteWebURL.FindStringSubmatch(remoteURL)
+	if match == nil || number <= 0 {
+		return ""
+	}
+	host, project := match[1], match[2]
+
+	switch {
+	case host == "github.com":
+		return fmt.Sprintf("https://%s/%s/pull/%d", host, project, number)
+	case strings.Contains(host, "gitlab"):
+		return fmt.Sprintf("https://%s/%s/-/merge_requests/%d", host, project, number)
+	default:
+		return ""
+	}
+}
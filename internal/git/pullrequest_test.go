@@ -0,0 +1,54 @@
+package git
+
+import "testing"
+
+func TestPullRequestNumber(t *testing.T) {
+	for _, tc := range []struct {
+		message string
+		want    int
+	}{
+		{"Merge pull request #123 from owner/feature\n\nAdd login form", 123},
+		{"Add login form (#45)", 45},
+		{"Add login form (!67)\n\nDetails", 67},
+		{"Merge branch 'feature' into 'main'\n\nAdd login form\n\nSee merge request group/project!89", 89},
+		{"Fix #12 in the parser", 0},
+		{"Add login form", 0},
+		// The reference must end the subject of squash merges
+		{"Revert (#45) partially", 0},
+	} {
+		if got := pullRequestNumber(tc.message); got != tc.want {
+			t.Errorf("pullRequestNumber(%q) = %d, want %d", tc.message, got, tc.want)
+		}
+	}
+}
+
+func TestPullRequestURL(t *testing.T) {
+	for _, tc := range []struct {
+		remote string
+		want   string
+	}{
+		{"https://github.com/owner/repo.git", "https://github.com/owner/repo/pull/7"},
+		{"git@github.com:owner/repo.git", "https://github.com/owner/repo/pull/7"},
+		{"ssh://git@gitlab.example.com:2222/group/sub/project.git", "https://gitlab.example.com/group/sub/project/-/merge_requests/7"},
+		{"https://gitlab.com/group/project", "https://gitlab.com/group/project/-/merge_requests/7"},
+		{"https://bitbucket.org/owner/repo.git", ""},
+	} {
+		if got := PullRequestURL(tc.remote, 7); got != tc.want {
+			t.Errorf("PullRequestURL(%q) = %q, want %q", tc.remote, got, tc.want)
+		}
+	}
+	if got := PullRequestURL("https://github.com/owner/repo.git", 0); got != "" {
+		t.Errorf("PullRequestURL without a number = %q, want empty", got)
+	}
+}
+
+func TestCommitPullRequestParsed(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Merge pull request #123 from owner/feature", day(2), nil)
+	r.commit("Add login form (!45)", day(3), nil)
+
+	commits := getCommits(t, r.service(), januaryQuery("master"))
+	if len(commits) != 2 || commits[0].PullRequest != 45 || commits[1].PullRequest != 123 {
+		t.Errorf("expected pull requests 45 and 123, got %+v", commits)
+	}
+}
@@ -0,0 +1,138 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CommitQuery describes which commits GetCommits should return
+type CommitQuery struct {
+	// Date range (inclusive, whole days)
+	From time.Time
+	To   time.Time
+
+	// Compare the range against each commit's calendar date in the author's
+	// own zone (from the commit offset) instead of the range boundaries' zone
+	AuthorLocalTime bool
+
+	// Author filters; every non-empty filter must match (AND semantics).
+	// The email has to match any of AuthorEmails. Emails and AuthorName are
+	// compared case-insensitively, while AuthorNamePattern is matched against
+	// the author name.
+	AuthorEmails      []string
+	AuthorName        string
+	AuthorNamePattern *regexp.Regexp
+
+	// Branch to walk from
+	Branch string
+
+	// Walk the history of all branches, remote-tracking branches and tags
+	// instead of only the commits reachable from Branch
+	AllRefs bool
+
+	// Only include commits since the merge base of Branch and this ref
+	MergeBaseWith string
+
+	// Revision range as in git log RangeFrom..RangeTo: walk from RangeTo
+	// instead of Branch, leaving out the commits reachable from RangeFrom
+	RangeFrom string
+	RangeTo   string
+
+	// Only include commits touching these repository-relative paths,
+	// optionally following renames of tracked files into older history
+	Paths         []string
+	FollowRenames bool
+
+	// Attach unified diffs to commits changing at most MaxDiffLines lines,
+	// with DiffContext unchanged lines around each change
+	IncludeDiffs bool
+	MaxDiffLines int
+	DiffContext  int
+
+	// Compute inserted/deleted line counts per commit
+	WithStats bool
+
+	// Compute bytes added/removed per commit from blob sizes
+	ByteStats bool
+
+	// Compute line and byte statistics against this fixed ref instead of
+	// each commit's parent, giving the cumulative change since the ref
+	StatAgainst string
+
+	// Order commits oldest first instead of newest first
+	Oldest bool
+
+	// Attach the git notes of commits from refs/notes/commits
+	IncludeNotes bool
+
+	// Subject shown for commits with an empty message
+	EmptyMessage string
+
+	// Leave Signed-off-by trailers in the description instead of moving
+	// them to Commit.SignOffs
+	KeepSignOffs bool
+
+	// Remove a matching prefix from commit subjects, keeping it as the ticket
+	SubjectPrefix *regexp.Regexp
+
+	// Use author identities as recorded instead of mapping them through
+	// the repository's .mailmap
+	IgnoreMailmap bool
+
+	// Maximum number of commits to return after sorting (0 for no limit)
+	Limit int
+}
+
+// inRange reports whether the commit was authored within the query date range
+func (q CommitQuery) inRange(c *object.Commit) bool {
+	if q.From.IsZero() && q.To.IsZero() {
+		// No date filter, e.g. for a revision range
+		return true
+	}
+	if q.AuthorLocalTime {
+		day := calendarDate(c.Author.When)
+		return !day.Before(calendarDate(q.From)) && !day.After(calendarDate(q.To))
+	}
+	// The range ends at the following midnight in the zone of To, which is
+	// not always 24 hours later across a DST change
+	return !c.Author.When.Before(q.From) && c.Author.When.Before(q.To.AddDate(0, 0, 1))
+}
+
+// calendarDate returns midnight UTC of the calendar day of t in its own zone,
+// so days from different zones can be compared
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// matchesAuthor reports whether the (canonicalized) author is the queried one
+func (q CommitQuery) matchesAuthor(author object.Signature) bool {
+	if len(q.AuthorEmails) > 0 && !q.matchesEmail(author.Email) {
+		return false
+	}
+	if q.AuthorName != "" && !strings.EqualFold(author.Name, q.AuthorName) {
+		return false
+	}
+	if q.AuthorNamePattern != nil && !q.AuthorNamePattern.MatchString(author.Name) {
+		return false
+	}
+	return true
+}
+
+// matchesEmail reports whether the email is one of the queried emails
+func (q CommitQuery) matchesEmail(email string) bool {
+	for _, queried := range q.AuthorEmails {
+		if strings.EqualFold(email, queried) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the commit by the given author satisfies all
+// query filters
+func (q CommitQuery) matches(c *object.Commit, author object.Signature) bool {
+	return q.inRange(c) && q.matchesAuthor(author)
+}
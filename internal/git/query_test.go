@@ -0,0 +1,104 @@
+package git
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestAuthorNameFilterAcrossEmails(t *testing.T) {
+	work := object.Signature{Name: "Jan Kowalski", Email: "jan.kowalski@company.com"}
+	other := object.Signature{Name: "Anna Nowak", Email: "anna@example.com"}
+	r := newTestRepo(t)
+	r.commit("Personal email", day(2), map[string]string{"a.txt": "1"})
+	r.commitAs(work, "Work email", day(3), map[string]string{"a.txt": "2"})
+	r.commitAs(other, "Someone else", day(4), map[string]string{"a.txt": "3"})
+	service := r.service()
+
+	for _, tc := range []struct {
+		name  string
+		query func(*CommitQuery)
+		want  []string
+	}{
+		{"name only", func(q *CommitQuery) {
+			q.AuthorName = "JAN KOWALSKI"
+		}, []string{"Work email", "Personal email"}},
+		{"pattern", func(q *CommitQuery) {
+			q.AuthorNamePattern = regexp.MustCompile(`(?i)^jan\b`)
+		}, []string{"Work email", "Personal email"}},
+		{"name and email", func(q *CommitQuery) {
+			q.AuthorName = "Jan Kowalski"
+			q.AuthorEmails = []string{work.Email}
+		}, []string{"Work email"}},
+	} {
+		query := januaryQuery("master")
+		query.AuthorEmails = nil
+		tc.query(&query)
+		if got := subjects(getCommits(t, service, query)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: commits = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAuthorLocalTimeAtRangeBoundaries(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	r := newTestRepo(t)
+	// 2023-12-31 16:00 UTC, but already January 1st for the author
+	r.commit("New Year in Tokyo", time.Date(2024, 1, 1, 1, 0, 0, 0, tokyo), map[string]string{"a.txt": "1"})
+	r.commit("Mid month", day(15), map[string]string{"a.txt": "2"})
+	// 2024-01-31 16:00 UTC, but already February 1st for the author
+	r.commit("February in Tokyo", time.Date(2024, 2, 1, 1, 0, 0, 0, tokyo), map[string]string{"a.txt": "3"})
+	service := r.service()
+
+	for _, tc := range []struct {
+		authorLocal bool
+		want        []string
+	}{
+		{false, []string{"February in Tokyo", "Mid month"}},
+		{true, []string{"Mid month", "New Year in Tokyo"}},
+	} {
+		query := januaryQuery("master")
+		query.AuthorLocalTime = tc.authorLocal
+		if got := subjects(getCommits(t, service, query)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("author local time %v: commits = %q, want %q", tc.authorLocal, got, tc.want)
+		}
+	}
+}
+
+func TestSingleDayRangeIncludesWholeDay(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Day before", time.Date(2024, 1, 14, 23, 59, 0, 0, time.UTC), map[string]string{"a.txt": "1"})
+	r.commit("Midnight", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), map[string]string{"a.txt": "2"})
+	r.commit("Late evening", time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC), map[string]string{"a.txt": "3"})
+	r.commit("Next day", time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), map[string]string{"a.txt": "4"})
+
+	query := januaryQuery("master")
+	query.From = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	query.To = query.From
+	got := subjects(getCommits(t, r.service(), query))
+	if want := []string{"Late evening", "Midnight"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("commits = %q, want %q", got, want)
+	}
+}
+
+func TestRangeEndsAtLocalMidnightAcrossDST(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skipf("zone data unavailable: %v", err)
+	}
+	r := newTestRepo(t)
+	// Clocks move forward on 2024-03-31, so the day is 23 hours long
+	r.commit("Late on the last day", time.Date(2024, 3, 31, 23, 30, 0, 0, warsaw), map[string]string{"a.txt": "1"})
+	r.commit("Just after midnight", time.Date(2024, 4, 1, 0, 30, 0, 0, warsaw), map[string]string{"a.txt": "2"})
+
+	query := januaryQuery("master")
+	query.From = time.Date(2024, 3, 1, 0, 0, 0, 0, warsaw)
+	query.To = time.Date(2024, 3, 31, 0, 0, 0, 0, warsaw)
+	got := subjects(getCommits(t, r.service(), query))
+	if want := []string{"Late on the last day"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("commits = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ParseRange splits a git revision range "A..B" into its endpoints. An
+// omitted endpoint defaults to HEAD, as in git log.
+func ParseRange(spec string) (from, to string, err error) {
+	if strings.Contains(spec, "...") {
+		return "", "", fmt.Errorf("invalid range %q: symmetric difference (A...B) is not supported, use A..B", spec)
+	}
+	from, to, found := strings.Cut(spec, "..")
+	if !found {
+		return "", "", fmt.Errorf("invalid range %q: expected <from>..<to>, e.g. main~10..main", spec)
+	}
+	if strings.Contains(to, "..") {
+		return "", "", fmt.Errorf("invalid range %q: expected a single ..", spec)
+	}
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" && to == "" {
+		return "", "", fmt.Errorf("invalid range %q: at least one endpoint is required", spec)
+	}
+	if from == "" {
+		from = "HEAD"
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	return from, to, nil
+}
+
+// ResolveRevision returns the full hash of the commit a revision such as
+// "main~3", "v1.0" or "HEAD^" points to
+func (s *Service) ResolveRevision(rev string) (string, error) {
+	hash, err := s.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve revision %s: %w", rev, err)
+	}
+	return hash.String(), nil
+}
+
+// ancestors returns the hashes of the commit and all commits reachable from it
+func (s *Service) ancestors(from plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	iter, err := s.repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer iter.Close()
+
+	reachable := make(map[plumbing.Hash]bool)
+	err = iter.ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate commit history: %w", err)
+	}
+	return reachable, nil
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	for _, tc := range []struct {
+		spec, from, to string
+	}{
+		{"HEAD~3..HEAD", "HEAD~3", "HEAD"},
+		{"main~10..main", "main~10", "main"},
+		{"v1.0..", "v1.0", "HEAD"},
+		{"..feature", "HEAD", "feature"},
+	} {
+		from, to, err := ParseRange(tc.spec)
+		if err != nil || from != tc.from || to != tc.to {
+			t.Errorf("ParseRange(%q) = %q, %q, %v, want %q, %q", tc.spec, from, to, err, tc.from, tc.to)
+		}
+	}
+
+	for spec, want := range map[string]string{
+		"main":    "expected <from>..<to>",
+		"a...b":   "symmetric difference",
+		"a..b..c": "a single ..",
+		"..":      "at least one endpoint",
+		"  ..  ":  "at least one endpoint",
+	} {
+		if _, _, err := ParseRange(spec); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseRange(%q) error = %v, want one mentioning %q", spec, err, want)
+		}
+	}
+}
+
+func TestResolveRangeHeadTilde3(t *testing.T) {
+	r := newTestRepo(t)
+	for i, subject := range []string{"one", "two", "three", "four", "five"} {
+		r.commit(subject, day(i+2), nil)
+	}
+	service := r.service()
+
+	from, to, err := ParseRange("HEAD~3..HEAD")
+	if err != nil {
+		t.Fatalf("failed to parse range: %v", err)
+	}
+	fromHash, err := service.ResolveRevision(from)
+	if err != nil {
+		t.Fatalf("failed to resolve %s: %v", from, err)
+	}
+	toHash, err := service.ResolveRevision(to)
+	if err != nil {
+		t.Fatalf("failed to resolve %s: %v", to, err)
+	}
+	if head, _ := service.GetHeadHash(); toHash != head {
+		t.Errorf("HEAD resolved to %s, want %s", toHash, head)
+	}
+
+	query := januaryQuery("")
+	query.RangeFrom, query.RangeTo = fromHash, toHash
+	if got, want := subjects(getCommits(t, service, query)), []string{"five", "four", "three"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HEAD~3..HEAD = %q, want %q", got, want)
+	}
+
+	if _, err := service.ResolveRevision("HEAD~10"); err == nil {
+		t.Error("expected an error for a revision beyond the history")
+	}
+}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// refsCommitIter walks the history of several start commits in turn,
+// visiting every commit once
+type refsCommitIter struct {
+	starts  []*object.Commit
+	seen    map[plumbing.Hash]bool
+	current object.CommitIter
+}
+
+// logAllRefs returns an iterator over the commits reachable from any local
+// branch, remote-tracking branch or tag. Unlike git log --all it leaves out
+// notes and stash refs, whose commits are not development history.
+func (s *Service) logAllRefs() (object.CommitIter, error) {
+	refs, err := s.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+	defer refs.Close()
+
+	iter := &refsCommitIter{seen: make(map[plumbing.Hash]bool)}
+	queued := make(map[plumbing.Hash]bool)
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name()
+		if ref.Type() != plumbing.HashReference || !(name.IsBranch() || name.IsRemote() || name.IsTag()) {
+			return nil
+		}
+
+		hash := ref.Hash()
+		if name.IsTag() {
+			// Annotated tags point at a tag object, which is peeled
+			if tag, err := s.repo.TagObject(hash); err == nil {
+				if tag.TargetType != plumbing.CommitObject {
+					return nil
+				}
+				hash = tag.Target
+			}
+		}
+		if queued[hash] {
+			return nil
+		}
+		commit, err := s.repo.CommitObject(hash)
+		if err != nil {
+			// Tags may point at trees or blobs
+			return nil
+		}
+		queued[hash] = true
+		iter.starts = append(iter.starts, commit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return iter, nil
+}
+
+// Next returns the next commit not visited through an earlier start commit
+func (it *refsCommitIter) Next() (*object.Commit, error) {
+	for {
+		if it.current == nil {
+			if len(it.starts) == 0 {
+				return nil, io.EOF
+			}
+			it.current = object.NewCommitPreorderIter(it.starts[0], it.seen, nil)
+			it.starts = it.starts[1:]
+		}
+
+		c, err := it.current.Next()
+		if err == io.EOF {
+			it.current = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		it.seen[c.Hash] = true
+		return c, nil
+	}
+}
+
+// ForEach calls cb for every commit until cb returns an error or
+// storer.ErrStop
+func (it *refsCommitIter) ForEach(cb func(*object.Commit) error) error {
+	defer it.Close()
+	for {
+		c, err := it.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := cb(c); err != nil {
+			if err == storer.ErrStop {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Close releases the iterator
+func (it *refsCommitIter) Close() {
+	if it.current != nil {
+		it.current.Close()
+		it.current = nil
+	}
+	it.starts = nil
+}
@@ -0,0 +1,29 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllRefsScopeIncludesUnmergedBranches(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("shared", day(2), map[string]string{"a.txt": "1"})
+	r.checkout("feature", true)
+	r.commit("unmerged feature", day(4), map[string]string{"b.txt": "1"})
+	r.checkout("master", false)
+	r.commit("master work", day(3), map[string]string{"a.txt": "2"})
+	// Notes are not development history
+	r.addNote(r.commit("noted", day(5), nil), "review notes")
+
+	service := r.service()
+	branch := januaryQuery("master")
+	if got, want := subjects(getCommits(t, service, branch)), []string{"noted", "master work", "shared"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("branch scope = %q, want %q", got, want)
+	}
+
+	repo := januaryQuery("master")
+	repo.AllRefs = true
+	if got, want := subjects(getCommits(t, service, repo)), []string{"noted", "unmerged feature", "master work", "shared"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("repo scope = %q, want %q", got, want)
+	}
+}
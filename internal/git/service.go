@@ -2,11 +2,15 @@ package git
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
@@ -14,11 +18,39 @@ import (
 // Commit represents a Git commit with relevant information
 type Commit struct {
 	SHA         string
+	Hash        string // Full commit hash
 	Date        time.Time
 	Message     string
 	Description string
+	Body        string   // Message after the subject with line breaks preserved
+	Ticket      string   // Subject prefix captured by CommitQuery.SubjectPrefix
+	Note        string   // Attached git note, set when notes are requested
+	SignOffs    []string // Identities of the stripped Signed-off-by trailers
+	PullRequest int      // Pull/merge request number from the message, 0 if none
+	NoMessage   bool     // The message was empty, Message holds the placeholder
+	Branch      string   // Branch the commit was found on
 	Author      string
 	AuthorEmail string
+	Signature   string // Raw PGP signature block, empty for unsigned commits
+	Diff        string // Unified diff, set only for small commits when diffs are requested
+	DiffLines   int    // Number of changed lines, set when diffs are requested
+
+	// Line statistics, set when stats are requested
+	Insertions int
+	Deletions  int
+	NetLines   int        // Insertions minus deletions
+	FileStats  []FileStat // Per-file breakdown of the line statistics
+
+	// Blob size growth and shrinkage, set when byte stats are requested
+	BytesAdded   int64
+	BytesRemoved int64
+}
+
+// FileStat holds the lines inserted and deleted in a single file
+type FileStat struct {
+	Path       string
+	Insertions int
+	Deletions  int
 }
 
 // Service provides Git repository operations
@@ -27,16 +59,37 @@ type Service struct {
 	repoPath string
 }
 
-// NewService creates a new Git service for the specified repository path
+// NewService creates a new Git service for the specified repository path.
+// Like git itself, it honors GIT_DIR and otherwise walks up from repoPath to
+// find the enclosing repository, including linked worktrees.
 func NewService(repoPath string) (*Service, error) {
-	repo, err := git.PlainOpen(repoPath)
+	openPath := repoPath
+	if gitDir := os.Getenv("GIT_DIR"); gitDir != "" {
+		openPath = gitDir
+	}
+
+	repo, err := git.PlainOpenWithOptions(openPath, &git.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err == git.ErrRepositoryNotExists {
+		// Detecting .git skips bare repositories such as mirror clones
+		repo, err = git.PlainOpen(openPath)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to open Git repository at %s: %w", repoPath, err)
+		return nil, fmt.Errorf("failed to open Git repository at %s: %w", openPath, err)
+	}
+
+	// Prefer the worktree root so the repository name does not depend on
+	// the subdirectory the tool was started from
+	rootPath := repoPath
+	if worktree, err := repo.Worktree(); err == nil {
+		rootPath = worktree.Filesystem.Root()
 	}
 
 	return &Service{
 		repo:     repo,
-		repoPath: repoPath,
+		repoPath: rootPath,
 	}, nil
 }
 
@@ -45,6 +98,106 @@ func (s *Service) GetRepositoryName() string {
 	return filepath.Base(s.repoPath)
 }
 
+// GetRepositoryPath returns the resolved path of the repository worktree
+func (s *Service) GetRepositoryPath() string {
+	return s.repoPath
+}
+
+// GetRemoteURL returns the first URL of the "origin" remote, or of the first
+// remote by name when there is no origin. It returns an empty string when the
+// repository has no remotes.
+func (s *Service) GetRemoteURL() (string, error) {
+	remotes, err := s.repo.Remotes()
+	if err != nil {
+		return "", fmt.Errorf("failed to list remotes: %w", err)
+	}
+	if len(remotes) == 0 {
+		return "", nil
+	}
+
+	sort.Slice(remotes, func(i, j int) bool {
+		return remotes[i].Config().Name < remotes[j].Config().Name
+	})
+	remote := remotes[0]
+	for _, r := range remotes {
+		if r.Config().Name == "origin" {
+			remote = r
+			break
+		}
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", nil
+	}
+	return urls[0], nil
+}
+
+// CountCommits returns the number of commits reachable from HEAD
+func (s *Service) CountCommits() (int, error) {
+	head, err := s.repo.Head()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get HEAD reference: %w", err)
+	}
+
+	commitIter, err := s.repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer commitIter.Close()
+
+	count := 0
+	err = commitIter.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate through commits: %w", err)
+	}
+
+	return count, nil
+}
+
+// Tag is a tag of the repository resolved to the commit it points to
+type Tag struct {
+	Name string
+	Hash string    // Full hash of the tagged commit
+	Date time.Time // Author date of the tagged commit, comparable to Commit.Date
+}
+
+// GetTags returns the tags pointing to commits, ordered by the date of the
+// tagged commit, oldest first
+func (s *Service) GetTags() ([]Tag, error) {
+	refs, err := s.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer refs.Close()
+
+	var tags []Tag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Annotated tags point to a tag object, lightweight tags to the commit
+		hash := ref.Hash()
+		if tagObject, err := s.repo.TagObject(hash); err == nil {
+			hash = tagObject.Target
+		}
+		commit, err := s.repo.CommitObject(hash)
+		if err != nil {
+			// Tags of trees or blobs do not mark releases
+			return nil
+		}
+
+		tags = append(tags, Tag{Name: ref.Name().Short(), Hash: commit.Hash.String(), Date: commit.Author.When})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate through tags: %w", err)
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].Date.Before(tags[j].Date) })
+	return tags, nil
+}
+
 // GetCurrentBranch returns the current branch name
 func (s *Service) GetCurrentBranch() (string, error) {
 	head, err := s.repo.Head()
@@ -56,64 +209,202 @@ func (s *Service) GetCurrentBranch() (string, error) {
 	return branchName, nil
 }
 
-// GetUserEmail returns the user email from Git configuration
+// GetHeadHash returns the full hash of the commit HEAD points to
+func (s *Service) GetHeadHash() (string, error) {
+	head, err := s.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD reference: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
+
+// GetBranchHash returns the full hash of the tip of the given branch
+func (s *Service) GetBranchHash(branchName string) (string, error) {
+	branchRefName := plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branchName))
+	branchRef, err := s.repo.Reference(branchRefName, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch reference for %s: %w", branchName, err)
+	}
+
+	return branchRef.Hash().String(), nil
+}
+
+// GetUserEmail returns the user email from the repository, global or system
+// Git configuration
 func (s *Service) GetUserEmail() (string, error) {
-	config, err := s.repo.Config()
+	// The repository config wins, then ~/.gitconfig (or the XDG config)
+	// and /etc/gitconfig, as with git config user.email
+	config, err := s.repo.ConfigScoped(gitconfig.SystemScope)
 	if err != nil {
-		return "", fmt.Errorf("failed to get repository config: %w", err)
+		return "", fmt.Errorf("failed to get git config: %w", err)
 	}
 
 	if config.User.Email == "" {
-		return "", fmt.Errorf("user email not configured in Git")
+		return "", fmt.Errorf("user email not configured in Git (set it with git config --global user.email)")
 	}
 
 	return config.User.Email, nil
 }
 
-// GetCommits retrieves commits for the specified author, date range, and branch
-func (s *Service) GetCommits(fromDate, toDate time.Time, authorEmail, branchName string) ([]*Commit, error) {
-	// Get the branch reference
-	branchRefName := plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branchName))
-	branchRef, err := s.repo.Reference(branchRefName, true)
+// GetConfigValue returns an option from the merged repository and user git
+// configuration, or an empty string when it is not set
+func (s *Service) GetConfigValue(section, option string) (string, error) {
+	config, err := s.repo.ConfigScoped(gitconfig.GlobalScope)
+	if err != nil {
+		return "", fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	return config.Raw.Section(section).Option(option), nil
+}
+
+// GetDirtyPaths returns the sorted paths with uncommitted changes in the worktree
+func (s *Service) GetDirtyPaths() ([]string, error) {
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	status, err := worktree.Status()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get branch reference for %s: %w", branchName, err)
+		return nil, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	var paths []string
+	for path, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// GetCommitsByAuthor retrieves commits for the specified author, date range, and branch.
+// It is a convenience wrapper around GetCommits.
+func (s *Service) GetCommitsByAuthor(fromDate, toDate time.Time, authorEmail, branchName string) ([]*Commit, error) {
+	query := CommitQuery{
+		From:   fromDate,
+		To:     toDate,
+		Branch: branchName,
+	}
+	if authorEmail != "" {
+		query.AuthorEmails = []string{authorEmail}
+	}
+	return s.GetCommits(query)
+}
+
+// GetCommits retrieves the commits matching the given query.
+// When MergeBaseWith is set, only commits between the merge base of the branch
+// and that ref and the branch tip are included (pull request semantics).
+// With AllRefs, commits on any ref are considered, not just the branch.
+// With RangeTo, the walk starts at that revision instead of the branch.
+func (s *Service) GetCommits(query CommitQuery) ([]*Commit, error) {
+	// Get the commit the walk starts from
+	var tip plumbing.Hash
+	if query.RangeTo != "" {
+		hash, err := s.repo.ResolveRevision(plumbing.Revision(query.RangeTo))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve revision %s: %w", query.RangeTo, err)
+		}
+		tip = *hash
+	} else {
+		branchRefName := plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", query.Branch))
+		branchRef, err := s.repo.Reference(branchRefName, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch reference for %s: %w", query.Branch, err)
+		}
+		tip = branchRef.Hash()
+	}
+
+	// Collect commits reachable from the merge base so they can be skipped
+	var excluded map[plumbing.Hash]bool
+	var err error
+	if query.MergeBaseWith != "" {
+		excluded, err = s.mergeBaseAncestors(tip, query.MergeBaseWith)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Skip commits reachable from the start of the revision range
+	if query.RangeFrom != "" {
+		hash, err := s.repo.ResolveRevision(plumbing.Revision(query.RangeFrom))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve revision %s: %w", query.RangeFrom, err)
+		}
+		excluded, err = s.ancestors(*hash)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Get commit iterator
-	commitIter, err := s.repo.Log(&git.LogOptions{
-		From: branchRef.Hash(),
-	})
+	var commitIter object.CommitIter
+	if query.AllRefs {
+		commitIter, err = s.logAllRefs()
+	} else {
+		commitIter, err = s.repo.Log(&git.LogOptions{
+			From: tip,
+		})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commit log: %w", err)
 	}
 	defer commitIter.Close()
 
+	builder, err := s.newCommitBuilder(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths *pathFilter
+	if len(query.Paths) > 0 {
+		paths = newPathFilter(query.Paths, query.FollowRenames)
+	}
+
 	var commits []*Commit
 
 	// Iterate through commits
 	err = commitIter.ForEach(func(c *object.Commit) error {
-		// Check if commit is within date range
-		if c.Author.When.Before(fromDate) || c.Author.When.After(toDate.Add(24*time.Hour)) {
+		// Skip commits that are already part of the merge base history
+		if excluded[c.Hash] {
 			return nil
 		}
 
-		// Check if commit is by the specified author
-		if !strings.EqualFold(c.Author.Email, authorEmail) {
-			return nil
+		// When following renames every commit has to be inspected, since a
+		// rename outside the other filters still changes the tracked names
+		if paths != nil && paths.followRenames {
+			touched, err := paths.touches(c)
+			if err != nil {
+				return err
+			}
+			if !touched {
+				return nil
+			}
 		}
 
-		// Parse commit message and description
-		message, description := parseCommitMessage(c.Message)
+		// Check date range and author filters
+		author := builder.identities.resolve(c.Author)
+		if !query.matches(c, author) {
+			return nil
+		}
 
-		commit := &Commit{
-			SHA:         c.Hash.String()[:8], // Short SHA
-			Date:        c.Author.When,
-			Message:     message,
-			Description: description,
-			Author:      c.Author.Name,
-			AuthorEmail: c.Author.Email,
+		if paths != nil && !paths.followRenames {
+			touched, err := paths.touches(c)
+			if err != nil {
+				return err
+			}
+			if !touched {
+				return nil
+			}
 		}
 
+		commit, err := builder.build(c, author)
+		if err != nil {
+			return err
+		}
 		commits = append(commits, commit)
 		return nil
 	})
@@ -122,26 +413,238 @@ func (s *Service) GetCommits(fromDate, toDate time.Time, authorEmail, branchName
 		return nil, fmt.Errorf("failed to iterate through commits: %w", err)
 	}
 
-	// Sort commits by date (newest first)
+	// Sort commits by date (newest first unless requested otherwise)
 	for i := 0; i < len(commits)-1; i++ {
 		for j := i + 1; j < len(commits); j++ {
-			if commits[i].Date.Before(commits[j].Date) {
+			if query.Oldest && commits[j].Date.Before(commits[i].Date) ||
+				!query.Oldest && commits[i].Date.Before(commits[j].Date) {
 				commits[i], commits[j] = commits[j], commits[i]
 			}
 		}
 	}
 
+	if query.Limit > 0 && len(commits) > query.Limit {
+		commits = commits[:query.Limit]
+	}
+
 	return commits, nil
 }
 
-// parseCommitMessage separates the commit message into title and description
-func parseCommitMessage(fullMessage string) (message, description string) {
+// commitBuilder turns go-git commits into report commits, attaching the
+// details requested by the query
+type commitBuilder struct {
+	query      CommitQuery
+	statBase   *object.Tree // Fixed base statistics are computed against, if any
+	identities *mailmap
+	notes      map[plumbing.Hash]string
+}
+
+// newCommitBuilder loads what building commits for the query requires
+func (s *Service) newCommitBuilder(query CommitQuery) (*commitBuilder, error) {
+	b := &commitBuilder{query: query, identities: &mailmap{}}
+
+	var err error
+	if query.StatAgainst != "" {
+		b.statBase, err = s.refTree(query.StatAgainst)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Canonicalize author identities unless disabled
+	if !query.IgnoreMailmap {
+		b.identities, err = s.loadMailmap()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if query.IncludeNotes {
+		b.notes, err = s.loadNotes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// build converts the commit of the given resolved author
+func (b *commitBuilder) build(c *object.Commit, author object.Signature) (*Commit, error) {
+	// Parse commit message and description
+	fullMessage, signOffs := c.Message, []string(nil)
+	if !b.query.KeepSignOffs {
+		fullMessage, signOffs = stripSignOffs(fullMessage)
+	}
+	message, description := parseCommitMessage(fullMessage, b.query.EmptyMessage)
+	message, ticket := stripSubjectPrefix(message, b.query.SubjectPrefix)
+
+	commit := &Commit{
+		SHA:         c.Hash.String()[:8], // Short SHA
+		Hash:        c.Hash.String(),
+		Date:        c.Author.When,
+		Message:     message,
+		Description: description,
+		Body:        commitBody(fullMessage),
+		Ticket:      ticket,
+		Author:      author.Name,
+		AuthorEmail: author.Email,
+		Signature:   c.PGPSignature,
+		Note:        b.notes[c.Hash],
+		SignOffs:    signOffs,
+		PullRequest: pullRequestNumber(c.Message),
+		NoMessage:   strings.TrimSpace(c.Message) == "",
+		Branch:      b.query.Branch,
+	}
+
+	if b.query.WithStats {
+		if err := attachLineStats(commit, c, b.statBase); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.query.ByteStats {
+		if err := attachByteStats(commit, c, b.statBase); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.query.IncludeDiffs {
+		if err := attachDiff(commit, c, b.query.MaxDiffLines, b.query.DiffContext); err != nil {
+			return nil, err
+		}
+	}
+
+	return commit, nil
+}
+
+// mergeBaseAncestors returns the set of commits reachable from the merge base(s)
+// of the given branch tip and the specified ref
+func (s *Service) mergeBaseAncestors(tip plumbing.Hash, ref string) (map[plumbing.Hash]bool, error) {
+	otherHash, err := s.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %s: %w", ref, err)
+	}
+
+	tipCommit, err := s.repo.CommitObject(tip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch tip commit: %w", err)
+	}
+
+	otherCommit, err := s.repo.CommitObject(*otherHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit for %s: %w", ref, err)
+	}
+
+	bases, err := tipCommit.MergeBase(otherCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute merge base with %s: %w", ref, err)
+	}
+
+	excluded := make(map[plumbing.Hash]bool)
+	for _, base := range bases {
+		reachable, err := s.ancestors(base.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk merge base history: %w", err)
+		}
+		for hash := range reachable {
+			excluded[hash] = true
+		}
+	}
+
+	return excluded, nil
+}
+
+// refTree returns the tree of the commit the given ref resolves to
+func (s *Service) refTree(ref string) (*object.Tree, error) {
+	hash, err := s.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %s: %w", ref, err)
+	}
+
+	commit, err := s.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit for %s: %w", ref, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree for %s: %w", ref, err)
+	}
+
+	return tree, nil
+}
+
+// stripSubjectPrefix removes the prefix matched by pattern from the subject
+// and returns it as the ticket: the first capture group when the pattern has
+// one, otherwise the trimmed match
+func stripSubjectPrefix(subject string, pattern *regexp.Regexp) (string, string) {
+	if pattern == nil {
+		return subject, ""
+	}
+
+	loc := pattern.FindStringSubmatchIndex(subject)
+	if loc == nil || loc[0] != 0 {
+		return subject, ""
+	}
+
+	ticket := strings.TrimSpace(subject[:loc[1]])
+	if len(loc) >= 4 && loc[2] >= 0 {
+		ticket = subject[loc[2]:loc[3]]
+	}
+	return strings.TrimSpace(subject[loc[1]:]), ticket
+}
+
+// signOffPrefix starts the DCO trailer lines of a commit message
+const signOffPrefix = "signed-off-by:"
+
+// stripSignOffs removes the Signed-off-by trailers from the last paragraph
+// of the commit message and returns them without the trailer key. The
+// subject line is never treated as a trailer.
+func stripSignOffs(fullMessage string) (string, []string) {
 	lines := strings.Split(strings.TrimSpace(fullMessage), "\n")
 
-	if len(lines) == 0 {
-		return "", ""
+	start := len(lines)
+	for start > 1 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start <= 1 {
+		return fullMessage, nil
+	}
+
+	var signOffs []string
+	kept := lines[:start]
+	for _, line := range lines[start:] {
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) >= len(signOffPrefix) && strings.EqualFold(trimmed[:len(signOffPrefix)], signOffPrefix) {
+			signOffs = append(signOffs, strings.TrimSpace(trimmed[len(signOffPrefix):]))
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if signOffs == nil {
+		return fullMessage, nil
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n")), signOffs
+}
+
+// commitBody returns the commit message without its subject line, keeping
+// the line structure of the body
+func commitBody(fullMessage string) string {
+	_, body, _ := strings.Cut(strings.TrimSpace(fullMessage), "\n")
+	return strings.Trim(body, "\n")
+}
+
+// parseCommitMessage separates the commit message into title and description.
+// An empty or whitespace-only message yields the placeholder as its title.
+func parseCommitMessage(fullMessage, placeholder string) (message, description string) {
+	if strings.TrimSpace(fullMessage) == "" {
+		return placeholder, ""
 	}
 
+	lines := strings.Split(strings.TrimSpace(fullMessage), "\n")
+
 	message = strings.TrimSpace(lines[0])
 
 	if len(lines) > 1 {
@@ -0,0 +1,341 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestGetCommitsMergeBaseWith(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Initial commit", day(1), map[string]string{"a.txt": "a"})
+	r.commit("Main before fork", day(2), map[string]string{"a.txt": "b"})
+	r.checkout("feature", true)
+	r.commit("Feature one", day(3), map[string]string{"f.txt": "1"})
+	r.checkout("master", false)
+	r.commit("Main after fork", day(4), map[string]string{"a.txt": "c"})
+	r.checkout("feature", false)
+	r.commit("Feature two", day(5), map[string]string{"f.txt": "2"})
+
+	query := januaryQuery("feature")
+	query.MergeBaseWith = "master"
+	got := subjects(getCommits(t, r.service(), query))
+
+	want := []string{"Feature two", "Feature one"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("commits = %q, want %q", got, want)
+	}
+}
+
+func TestGetCommitsQueryOptions(t *testing.T) {
+	other := object.Signature{Name: "Anna Nowak", Email: "anna@example.com"}
+	r := newTestRepo(t)
+	r.commit("December work", time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC), map[string]string{"a.txt": "0"})
+	r.commit("First", day(2), map[string]string{"a.txt": "1"})
+	r.commitAs(other, "By Anna", day(3), map[string]string{"b.txt": "1"})
+	r.commit("Second", day(4), map[string]string{"a.txt": "2"})
+	r.commit("Third", day(6), map[string]string{"a.txt": "3"})
+	r.commit("February work", time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC), map[string]string{"a.txt": "4"})
+	service := r.service()
+
+	tests := []struct {
+		name  string
+		query func(*CommitQuery)
+		want  []string
+	}{
+		{"date range and author", func(q *CommitQuery) {}, []string{"Third", "Second", "First"}},
+		{"oldest first", func(q *CommitQuery) { q.Oldest = true }, []string{"First", "Second", "Third"}},
+		{"limit", func(q *CommitQuery) { q.Limit = 2 }, []string{"Third", "Second"}},
+		{"several authors", func(q *CommitQuery) {
+			q.AuthorEmails = []string{testAuthor.Email, "ANNA@example.com"}
+		}, []string{"Third", "Second", "By Anna", "First"}},
+		{"author name", func(q *CommitQuery) {
+			q.AuthorEmails = nil
+			q.AuthorName = "anna nowak"
+		}, []string{"By Anna"}},
+		{"narrow range", func(q *CommitQuery) {
+			q.From, q.To = day(4), day(4)
+		}, []string{"Second"}},
+	}
+	for _, tc := range tests {
+		query := januaryQuery("master")
+		tc.query(&query)
+		if got := subjects(getCommits(t, service, query)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: commits = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestGetCommitsByAuthorWrapsGetCommits(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("First", day(2), map[string]string{"a.txt": "1"})
+	r.commit("Second", day(4), map[string]string{"a.txt": "2"})
+	service := r.service()
+
+	query := januaryQuery("master")
+	commits, err := service.GetCommitsByAuthor(query.From, query.To, testAuthor.Email, "master")
+	if err != nil {
+		t.Fatalf("GetCommitsByAuthor failed: %v", err)
+	}
+	want := subjects(getCommits(t, service, query))
+	if got := subjects(commits); !reflect.DeepEqual(got, want) {
+		t.Errorf("commits = %q, want %q", got, want)
+	}
+}
+
+func TestGetDirtyPaths(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Initial commit", day(1), map[string]string{"a.txt": "a", "b.txt": "b"})
+	service := r.service()
+
+	paths, err := service.GetDirtyPaths()
+	if err != nil {
+		t.Fatalf("GetDirtyPaths failed: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("clean worktree reported dirty paths %q", paths)
+	}
+
+	if err := os.WriteFile(filepath.Join(r.dir, "b.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("failed to modify b.txt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to write new.txt: %v", err)
+	}
+
+	paths, err = service.GetDirtyPaths()
+	if err != nil {
+		t.Fatalf("GetDirtyPaths failed: %v", err)
+	}
+	if want := []string{"b.txt", "new.txt"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("dirty paths = %q, want %q", paths, want)
+	}
+}
+
+func TestGetHeadHash(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Initial commit", day(1), map[string]string{"a.txt": "a"})
+	tip := r.commit("Second", day(2), map[string]string{"a.txt": "b"})
+
+	hash, err := r.service().GetHeadHash()
+	if err != nil {
+		t.Fatalf("GetHeadHash failed: %v", err)
+	}
+	if hash != tip.String() {
+		t.Errorf("HEAD hash = %s, want %s", hash, tip)
+	}
+}
+
+func TestNewServiceFromNestedSubdirectory(t *testing.T) {
+	r := newTestRepo(t)
+	tip := r.commit("Initial commit", day(1), map[string]string{"src/pkg/a.txt": "a"})
+	t.Setenv("GIT_DIR", "")
+
+	service, err := NewService(filepath.Join(r.dir, "src", "pkg"))
+	if err != nil {
+		t.Fatalf("failed to open repository from a subdirectory: %v", err)
+	}
+	hash, err := service.GetHeadHash()
+	if err != nil {
+		t.Fatalf("GetHeadHash failed: %v", err)
+	}
+	if hash != tip.String() {
+		t.Errorf("HEAD hash = %s, want %s", hash, tip)
+	}
+	if service.repoPath != r.dir {
+		t.Errorf("repository root = %s, want %s", service.repoPath, r.dir)
+	}
+}
+
+func TestNewServiceHonorsGitDir(t *testing.T) {
+	r := newTestRepo(t)
+	tip := r.commit("Initial commit", day(1), map[string]string{"a.txt": "a"})
+	t.Setenv("GIT_DIR", filepath.Join(r.dir, ".git"))
+
+	service, err := NewService(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open repository from GIT_DIR: %v", err)
+	}
+	if hash, err := service.GetHeadHash(); err != nil || hash != tip.String() {
+		t.Errorf("HEAD hash = %s (%v), want %s", hash, err, tip)
+	}
+}
+
+func TestStripSubjectPrefix(t *testing.T) {
+	pattern := regexp.MustCompile(`^\[([A-Z]+-\d+)\]\s*`)
+	for _, tc := range []struct {
+		subject, want, ticket string
+	}{
+		{"[JIRA-123] Add login form", "Add login form", "JIRA-123"},
+		{"Add login form [JIRA-123]", "Add login form [JIRA-123]", ""},
+		{"Add login form", "Add login form", ""},
+	} {
+		subject, ticket := stripSubjectPrefix(tc.subject, pattern)
+		if subject != tc.want || ticket != tc.ticket {
+			t.Errorf("stripSubjectPrefix(%q) = %q, %q, want %q, %q", tc.subject, subject, ticket, tc.want, tc.ticket)
+		}
+	}
+
+	// Without a capture group the whole prefix is the ticket
+	if subject, ticket := stripSubjectPrefix("PROJ-7: Fix typo", regexp.MustCompile(`^[A-Z]+-\d+:`)); subject != "Fix typo" || ticket != "PROJ-7:" {
+		t.Errorf("stripSubjectPrefix without group = %q, %q, want \"Fix typo\", \"PROJ-7:\"", subject, ticket)
+	}
+}
+
+func TestSubjectPrefixCapturedAsTicket(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("[JIRA-123] Add login form", day(2), map[string]string{"a.txt": "1"})
+
+	query := januaryQuery("master")
+	query.SubjectPrefix = regexp.MustCompile(`^\[([A-Z]+-\d+)\]\s*`)
+	commit := getCommits(t, r.service(), query)[0]
+	if commit.Message != "Add login form" || commit.Ticket != "JIRA-123" {
+		t.Errorf("commit = %q with ticket %q, want \"Add login form\" with ticket JIRA-123", commit.Message, commit.Ticket)
+	}
+}
+
+func TestGetTagsOrderedByCommitDate(t *testing.T) {
+	r := newTestRepo(t)
+	first := r.commit("first", day(2), nil)
+	second := r.commit("second", day(5), nil)
+
+	// Created out of order, one annotated and one lightweight
+	tagger := testAuthor
+	tagger.When = day(6)
+	if _, err := r.repo.CreateTag("v1.2.0", second, &git.CreateTagOptions{Tagger: &tagger, Message: "Release"}); err != nil {
+		t.Fatalf("failed to create annotated tag: %v", err)
+	}
+	if _, err := r.repo.CreateTag("v1.1.0", first, nil); err != nil {
+		t.Fatalf("failed to create lightweight tag: %v", err)
+	}
+
+	tags, err := r.service().GetTags()
+	if err != nil {
+		t.Fatalf("failed to get tags: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags, got %+v", tags)
+	}
+	if tags[0].Name != "v1.1.0" || tags[0].Hash != first.String() {
+		t.Errorf("expected v1.1.0 on the first commit first, got %+v", tags[0])
+	}
+	if tags[1].Name != "v1.2.0" || tags[1].Hash != second.String() || !tags[1].Date.Equal(day(5)) {
+		t.Errorf("expected v1.2.0 resolved to the second commit, got %+v", tags[1])
+	}
+}
+
+func TestStripSignOffs(t *testing.T) {
+	for _, tc := range []struct {
+		message, want string
+		signOffs      []string
+	}{
+		{
+			"Fix parser\n\nHandle empty input.\n\nSigned-off-by: Jan Kowalski <jan@example.com>\nsigned-off-by: Anna Nowak <anna@example.com>\n",
+			"Fix parser\n\nHandle empty input.",
+			[]string{"Jan Kowalski <jan@example.com>", "Anna Nowak <anna@example.com>"},
+		},
+		{
+			"Fix parser\n\nReviewed-by: Anna\nSigned-off-by: Jan <jan@example.com>",
+			"Fix parser\n\nReviewed-by: Anna",
+			[]string{"Jan <jan@example.com>"},
+		},
+		// Only trailers in the last paragraph count
+		{"Fix parser\n\nSigned-off-by: Jan\n\nMore text", "Fix parser\n\nSigned-off-by: Jan\n\nMore text", nil},
+		{"Signed-off-by: Jan", "Signed-off-by: Jan", nil},
+	} {
+		message, signOffs := stripSignOffs(tc.message)
+		if message != tc.want || !reflect.DeepEqual(signOffs, tc.signOffs) {
+			t.Errorf("stripSignOffs(%q) = %q, %q, want %q, %q", tc.message, message, signOffs, tc.want, tc.signOffs)
+		}
+	}
+}
+
+func TestSignOffsRemovedFromBody(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Fix parser\n\nHandle empty input.\n\nSigned-off-by: Jan Kowalski <jan@example.com>", day(2), nil)
+
+	commit := getCommits(t, r.service(), januaryQuery("master"))[0]
+	if commit.Description != "Handle empty input." || commit.Body != "Handle empty input." {
+		t.Errorf("description = %q, body = %q, want the sign-off removed", commit.Description, commit.Body)
+	}
+	if want := []string{"Jan Kowalski <jan@example.com>"}; !reflect.DeepEqual(commit.SignOffs, want) {
+		t.Errorf("sign-offs = %q, want %q", commit.SignOffs, want)
+	}
+
+	query := januaryQuery("master")
+	query.KeepSignOffs = true
+	commit = getCommits(t, r.service(), query)[0]
+	if !strings.Contains(commit.Body, "Signed-off-by: Jan Kowalski") || commit.SignOffs != nil {
+		t.Errorf("body = %q with sign-offs %q, want the sign-off kept in the body", commit.Body, commit.SignOffs)
+	}
+}
+
+func TestParseCommitMessageEmpty(t *testing.T) {
+	for _, message := range []string{"", "  \n\t\n"} {
+		subject, description := parseCommitMessage(message, "(no message)")
+		if subject != "(no message)" || description != "" {
+			t.Errorf("parseCommitMessage(%q) = %q, %q, want the placeholder", message, subject, description)
+		}
+	}
+	if subject, _ := parseCommitMessage("Fix typo\n", "(no message)"); subject != "Fix typo" {
+		t.Errorf("subject = %q, want Fix typo", subject)
+	}
+}
+
+func TestEmptyMessagePlaceholder(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("   \n", day(2), nil)
+	r.commit("Fix typo", day(3), nil)
+
+	query := januaryQuery("master")
+	query.EmptyMessage = "(no message)"
+	commits := getCommits(t, r.service(), query)
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+	if empty := commits[1]; empty.Message != "(no message)" || !empty.NoMessage {
+		t.Errorf("empty commit = %q (no message %v), want the placeholder", empty.Message, empty.NoMessage)
+	}
+	if commits[0].NoMessage {
+		t.Error("expected a commit with a subject not to be marked empty")
+	}
+}
+
+func TestGetUserEmailFallsBackToGlobalConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	r := newTestRepo(t)
+	service := r.service()
+
+	if _, err := service.GetUserEmail(); err == nil || !strings.Contains(err.Error(), "git config --global user.email") {
+		t.Errorf("expected a hint to set the global email, got %v", err)
+	}
+
+	global := "[user]\n\temail = global@example.com\n"
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(global), 0644); err != nil {
+		t.Fatalf("failed to write global config: %v", err)
+	}
+	if email, err := service.GetUserEmail(); err != nil || email != "global@example.com" {
+		t.Errorf("email = %q, %v, want the global email", email, err)
+	}
+
+	config, err := r.repo.Config()
+	if err != nil {
+		t.Fatalf("failed to read repository config: %v", err)
+	}
+	config.User.Email = "local@example.com"
+	if err := r.repo.SetConfig(config); err != nil {
+		t.Fatalf("failed to write repository config: %v", err)
+	}
+	if email, err := service.GetUserEmail(); err != nil || email != "local@example.com" {
+		t.Errorf("email = %q, %v, want the repository email to win", email, err)
+	}
+}
@@ -0,0 +1,38 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// GetCommitsByHashes resolves the given full or abbreviated hashes and
+// returns their commits in the same order. Date, author and path filters of
+// the query are ignored; only the options shaping the commits apply.
+func (s *Service) GetCommitsByHashes(hashes []string, query CommitQuery) ([]*Commit, error) {
+	builder, err := s.newCommitBuilder(query)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]*Commit, 0, len(hashes))
+	for _, hash := range hashes {
+		resolved, err := s.repo.ResolveRevision(plumbing.Revision(hash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve commit %s: %w", hash, err)
+		}
+
+		c, err := s.repo.CommitObject(*resolved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+		}
+
+		commit, err := builder.build(c, builder.identities.resolve(c.Author))
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,39 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestGetCommitsByHashesInListOrder(t *testing.T) {
+	r := newTestRepo(t)
+	first := r.commit("first", day(2), map[string]string{"a.txt": "1"})
+	other := r.commitAs(object.Signature{Name: "Anna Nowak", Email: "anna@example.com"}, "other author", day(3), map[string]string{"a.txt": "2"})
+	third := r.commit("third", day(20), map[string]string{"a.txt": "3"})
+
+	// The author and range of the query do not filter listed commits
+	query := januaryQuery("master")
+	query.To = day(10)
+	hashes := []string{third.String(), first.String()[:7], other.String()[:10]}
+	commits, err := r.service().GetCommitsByHashes(hashes, query)
+	if err != nil {
+		t.Fatalf("failed to get commits: %v", err)
+	}
+	if got, want := subjects(commits), []string{"third", "first", "other author"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subjects = %q, want %q", got, want)
+	}
+	if commits[1].Hash != first.String() {
+		t.Errorf("short hash resolved to %s, want %s", commits[1].Hash, first)
+	}
+}
+
+func TestGetCommitsByHashesUnresolved(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("first", day(2), nil)
+
+	if _, err := r.service().GetCommitsByHashes([]string{"deadbeef"}, januaryQuery("master")); err == nil {
+		t.Error("expected an error for a hash not in the repository")
+	}
+}